@@ -0,0 +1,43 @@
+package st2
+
+import (
+	"fmt"
+	"strings"
+)
+
+var thriftLiteralReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// Annotation is an annotation of thrift, such as `(go.tag="json:\"a\"")`,
+// the values of the same key are merged
+type Annotation struct {
+	Key    string
+	Values []string
+}
+
+// Annotations is the annotations of a [Member] or a [Struct]
+type Annotations []*Annotation
+
+// Get get the values of the key
+func (a Annotations) Get(key string) []string {
+	for _, annotation := range a {
+		if annotation.Key == key {
+			return annotation.Values
+		}
+	}
+	return nil
+}
+
+// Thrift get the thrift annotations string with a leading space, such as
+// ` (go.tag="json:\"a\"")`, it is empty if there is no annotation
+func (a Annotations) Thrift() string {
+	items := make([]string, 0, len(a))
+	for _, annotation := range a {
+		for _, value := range annotation.Values {
+			items = append(items, fmt.Sprintf(`%s="%s"`, annotation.Key, thriftLiteralReplacer.Replace(value)))
+		}
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(items, ", ") + ")"
+}
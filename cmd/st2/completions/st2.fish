@@ -1,13 +1,21 @@
 complete st2 -f
 complete st2 -r -f -s r -l root -d 'The root struct name (default: Root)'
-complete st2 -r -F -s i -l input -d 'Input file, if not set, it will read from stdio'
+complete st2 -r -F -s i -l input -d 'Input file or go package directory, if not set, it will read from stdio'
+complete st2 -r -a '(__fish_complete_directories)' -s I -l import-path -d 'Add a directory to search the imported files, only works for proto and thrift source'
+complete st2 -r -f -l embed -a "flatten reference" -d 'The policy of embedded struct, only works for go source'
+complete st2 -r -f -l tag-key -a "json protobuf thrift yaml" -d 'The key of struct tag which names the fields, only works for go source'
+complete st2 -l typedef -d 'Keep the named types which are not struct or enum as typedef, only works for go source'
 complete st2 -l rc -d 'Read input from clipboard'
-complete st2 -r -f -s s -l src -a "json yaml proto thrift go csv xml toml" -d 'The source data type, it will use the suffix of the input file if not set'
-complete st2 -r -f -s d -l dst -a "go proto thrift" -d 'The destination data type, it will use the suffix of the output file if not set'
+complete st2 -l detect-format -d 'Detect the format of string value, only works for structured source'
+complete st2 -l merge -d 'Merge multiple samples into one inferred schema, only works for json and yaml source'
+complete st2 -r -f -s s -l src -a "jsonschema json yaml proto thrift go csv xml toml" -d 'The source data type, it will use the suffix of the input file if not set'
+complete st2 -r -f -s d -l dst -a "go proto thrift typescript ts jsonschema rust rs java kotlin kt" -d 'The destination data type, it will use the suffix of the output file if not set'
 complete st2 -r -F -s o -l output -d 'Output file, if not set, it will write to stdout'
 complete st2 -l wc -d 'Write output to clipboard'
 complete st2 -r -f -l prefix -d 'Add prefix to struct name'
 complete st2 -r -f -l suffix -d 'Add suffix to struct name'
+complete st2 -r -f -l package -d 'Set the package of the output'
+complete st2 -r -f -l go-package -d 'Set the go import path of the output'
 complete st2 -r -f -l xml-content-tag-prefix -d 'Add `prefix` to xml content tag in go field, only works for xml source and go destination'
 complete st2 -r -f -l xml-attribute-tag-prefix -d 'Add `prefix` to xml attribute tag in go field, only works for xml source and go destination'
 complete st2 -s h -l help -d 'show help'
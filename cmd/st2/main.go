@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/tenfyzhong/st2"
@@ -25,26 +26,123 @@ const (
 	flagSuffix                = "suffix"
 	flagXMLContentTagPrefix   = "xml-content-tag-prefix"
 	flagXMLAttributeTagPrefix = "xml-attribute-tag-prefix"
+	flagMerge                 = "merge"
+	flagDetectFormat          = "detect-format"
+	flagPackage               = "package"
+	flagGoPackage             = "go-package"
+	flagImportPath            = "import-path"
+	flagEmbed                 = "embed"
+	flagTypedef               = "typedef"
+	flagTagKey                = "tag-key"
 
 	categoryCommon = "common"
 	categoryInput  = "input"
 	categoryOutput = "output"
 )
 
-func getReader(cmd *cli.Command) (io.ReadCloser, error) {
+func getReader(cmd *cli.Command, src string) (io.ReadCloser, error) {
 	if cmd.Bool(flagReadClipboard) {
 		return NewClipboardReadCloser(), nil
 	}
 
-	readfile := cmd.String(flagInput)
-	if readfile == "" {
+	readfiles := getInputs(cmd)
+	if len(readfiles) == 0 {
 		return os.Stdin, nil
 	}
-	file, err := os.Open(readfile)
-	if err != nil {
-		return nil, errors.New(err.Error() + "\n\n")
+	if len(readfiles) == 1 {
+		file, err := os.Open(readfiles[0])
+		if err != nil {
+			return nil, errors.New(err.Error() + "\n\n")
+		}
+		return file, nil
+	}
+
+	// join multiple sample files to a multi-document source
+	separator := ""
+	switch src {
+	case st2.LangJson:
+		separator = "\n"
+	case st2.LangYaml:
+		separator = "\n---\n"
+	default:
+		return nil, fmt.Errorf("multiple inputs only work for json and yaml source\n\n")
+	}
+	files := make(multiReadCloser, 0, len(readfiles))
+	for _, readfile := range readfiles {
+		file, err := os.Open(readfile)
+		if err != nil {
+			files.Close()
+			return nil, errors.New(err.Error() + "\n\n")
+		}
+		files = append(files, file)
+	}
+	return files.separatedBy(separator), nil
+}
+
+// getInputs get the input file from the input flag and the arguments
+func getInputs(cmd *cli.Command) []string {
+	inputs := make([]string, 0, cmd.Args().Len()+1)
+	if input := cmd.String(flagInput); input != "" {
+		inputs = append(inputs, input)
+	}
+	return append(inputs, cmd.Args().Slice()...)
+}
+
+// getPackage get the package pattern from the input, it is a directory, or
+// a directory followed by `/...` to match all the sub directories, it
+// returns empty if the input is not a package
+func getPackage(cmd *cli.Command) string {
+	inputs := getInputs(cmd)
+	if len(inputs) != 1 {
+		return ""
+	}
+	input := inputs[0]
+	if strings.HasSuffix(filepath.ToSlash(input), "/...") {
+		return input
+	}
+	if info, err := os.Stat(input); err == nil && info.IsDir() {
+		return input
+	}
+	return ""
+}
+
+// getImportPaths get the directories to search the imported files, the
+// directory of the input file is searched at last
+func getImportPaths(cmd *cli.Command) []string {
+	paths := cmd.StringSlice(flagImportPath)
+	if inputs := getInputs(cmd); len(inputs) > 0 {
+		paths = append(paths, filepath.Dir(inputs[0]))
+	}
+	return paths
+}
+
+type multiReadCloser []io.ReadCloser
+
+func (m multiReadCloser) Close() error {
+	var err error
+	for _, r := range m {
+		if e := r.Close(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func (m multiReadCloser) separatedBy(separator string) io.ReadCloser {
+	readers := make([]io.Reader, 0, len(m)*2)
+	for i, r := range m {
+		if i > 0 {
+			readers = append(readers, strings.NewReader(separator))
+		}
+		readers = append(readers, r)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(readers...),
+		Closer: m,
 	}
-	return file, nil
 }
 
 func getWriter(cmd *cli.Command) (io.WriteCloser, error) {
@@ -77,6 +175,10 @@ func action(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("src equals to dst\n\n")
 	}
 
+	if embed := cmd.String(flagEmbed); embed != st2.EmbedFlatten && embed != st2.EmbedReference {
+		return fmt.Errorf("flag: %s must be %s or %s\n\n", flagEmbed, st2.EmbedFlatten, st2.EmbedReference)
+	}
+
 	st2Ctx := st2.NewContext(
 		src,
 		dst,
@@ -88,8 +190,27 @@ func action(ctx context.Context, cmd *cli.Command) error {
 			AttributeTagPrefix: cmd.String(flagXMLAttributeTagPrefix),
 		},
 	)
+	// multiple input files are always merged
+	st2Ctx.Merge = cmd.Bool(flagMerge) || len(getInputs(cmd)) > 1
+	st2Ctx.DetectFormat = cmd.Bool(flagDetectFormat)
+	st2Ctx.Package = cmd.String(flagPackage)
+	st2Ctx.GoPackage = cmd.String(flagGoPackage)
+	st2Ctx.ImportPaths = getImportPaths(cmd)
+	st2Ctx.Embed = cmd.String(flagEmbed)
+	st2Ctx.Typedef = cmd.Bool(flagTypedef)
+	st2Ctx.TagKey = cmd.String(flagTagKey)
+
+	if pattern := getPackage(cmd); pattern != "" {
+		writer, err := getWriter(cmd)
+		if err != nil {
+			return err
+		}
+		defer writer.Close()
 
-	reader, err := getReader(cmd)
+		return st2.ConvertPackage(st2Ctx, pattern, writer)
+	}
+
+	reader, err := getReader(cmd, src)
 	if err != nil {
 		return err
 	}
@@ -125,9 +246,9 @@ func (f FlagList) Swap(i, j int) {
 func main() {
 	cmd := &cli.Command{
 		Name:        "st2",
-		Usage:       "convert between json, yaml, csv, xml, toml, protobuf, thrift, go struct",
+		Usage:       "convert between json, yaml, csv, xml, toml, protobuf, thrift, go struct, typescript, jsonschema, rust, java, kotlin",
 		UsageText:   "",
-		ArgsUsage:   "",
+		ArgsUsage:   "[sample files to merge...]",
 		Version:     config.Version,
 		Description: "",
 		Flags: []cli.Flag{
@@ -151,7 +272,42 @@ func main() {
 				Category:  categoryInput,
 				Required:  false,
 				TakesFile: true,
-				Usage:     "Input `file`, if not set, it will read from stdio",
+				Usage:     "Input `file`, if not set, it will read from stdio, it can be a package directory or a directory followed by /... to include the sub packages for go source",
+			},
+			&cli.StringSliceFlag{
+				Name:     flagImportPath,
+				Aliases:  []string{"I"},
+				Category: categoryInput,
+				Usage:    "Add a `directory` to search the imported files, it can be set multiple times, the directory of the input file is always searched, only works for proto and thrift source",
+			},
+			&cli.BoolFlag{
+				Name:     flagMerge,
+				Category: categoryInput,
+				Usage:    "Merge multiple samples into one inferred schema, the samples come from a top level array, ndjson, multi-document yaml or multiple input files, only works for json and yaml source",
+			},
+			&cli.BoolFlag{
+				Name:     flagDetectFormat,
+				Category: categoryInput,
+				Usage:    "Detect the format of string value, date time becomes timestamp, base64 becomes binary, uuid and url are annotated in comment, only works for structured source",
+			},
+			&cli.StringFlag{
+				Name:        flagEmbed,
+				Category:    categoryInput,
+				DefaultText: st2.EmbedFlatten,
+				Value:       st2.EmbedFlatten,
+				Usage:       fmt.Sprintf("The `policy` of embedded struct, %s flattens its fields into the embedding struct, %s references it as a field, only works for go source", st2.EmbedFlatten, st2.EmbedReference),
+			},
+			&cli.StringFlag{
+				Name:        flagTagKey,
+				Category:    categoryInput,
+				DefaultText: st2.TagKeyDefault,
+				Value:       st2.TagKeyDefault,
+				Usage:       "The `key` of struct tag which names the fields, such as json, protobuf and thrift, the field with - is ignored, the field with omitempty is optional, only works for go source",
+			},
+			&cli.BoolFlag{
+				Name:     flagTypedef,
+				Category: categoryInput,
+				Usage:    "Keep the named types which are not struct or enum as typedef, it is a comment in protobuf, otherwise they are resolved to their underlying types, only works for go source",
 			},
 			&cli.StringFlag{
 				Name:      flagXMLContentTagPrefix,
@@ -205,6 +361,16 @@ func main() {
 				Category: categoryOutput,
 				Usage:    "Add `suffix` to struct name",
 			},
+			&cli.StringFlag{
+				Name:     flagPackage,
+				Category: categoryOutput,
+				Usage:    "Set the `package` of the output, it is the protobuf package, the thrift * namespace and the go package name, it overrides the package of the source",
+			},
+			&cli.StringFlag{
+				Name:     flagGoPackage,
+				Category: categoryOutput,
+				Usage:    "Set the go import `path` of the output, it is the protobuf go_package option and the thrift go namespace, it overrides the go package of the source",
+			},
 		},
 		EnableShellCompletion:      true,
 		ShellCompletionCommandName: "st2",
@@ -252,18 +418,34 @@ func dstTypeFromName(name string) string {
 	return matchLangName(st2.DestinationLangs, name)
 }
 
+// aliasLangName convert the alias name to the lang name, such as `yml` to `yaml`
+func aliasLangName(langs []st2.Lang, name string) string {
+	for _, lang := range langs {
+		for _, alias := range lang.Aliases {
+			if alias == name {
+				return lang.Lang
+			}
+		}
+	}
+	return name
+}
+
 func getSrc(cmd *cli.Command) string {
 	src := cmd.String(flagSrc)
 	if src != "" {
-		return src
+		return aliasLangName(st2.SourceLangs, src)
+	}
+	inputs := getInputs(cmd)
+	if len(inputs) == 0 {
+		return ""
 	}
-	return srcTypeFromName(cmd.String(flagInput))
+	return srcTypeFromName(inputs[0])
 }
 
 func getDst(cmd *cli.Command) string {
 	dst := cmd.String(flagDst)
 	if dst != "" {
-		return dst
+		return aliasLangName(st2.DestinationLangs, dst)
 	}
 	return dstTypeFromName(cmd.String(flagOutput))
 }
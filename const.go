@@ -13,55 +13,135 @@ const (
 	LangXML    = "xml"
 	LangToml   = "toml"
 
+	LangTypeScript = "typescript"
+	LangTs         = "ts"
+	LangJsonSchema = "jsonschema"
+	LangSchemaJson = "schema.json"
+	LangRust       = "rust"
+	LangRs         = "rs"
+	LangJava       = "java"
+	LangKotlin     = "kotlin"
+	LangKt         = "kt"
+
 	RootDefault = "Root"
 
+	FormatDateTime = "date-time"
+	FormatBase64   = "base64"
+	FormatUUID     = "uuid"
+	FormatURL      = "url"
+
 	FlagXMLAttributeTagPrefixDefault = ","
+
+	EmbedFlatten   = "flatten"
+	EmbedReference = "reference"
+
+	TagKeyDefault       = "json"
+	TagKeyProtobuf      = "protobuf"
+	TagKeyProtobufOneof = "protobuf_oneof"
+	TagKeyThrift        = "thrift"
+	TagKeySt2           = "st2"
+
+	ThriftStreamingMode    = "streaming.mode"
+	ThriftGoTag            = "go.tag"
+	StreamingBidirectional = "bidirectional"
+	StreamingClient        = "client"
+	StreamingServer        = "server"
 )
 
 const (
-	StrInt        = "int"
-	StrInt8       = "int8"
-	StrInt16      = "int16"
-	StrInt32      = "int32"
-	StrInt64      = "int64"
-	StrUint       = "uint"
-	StrUint8      = "uint8"
-	StrUint16     = "uint16"
-	StrUint32     = "uint32"
-	StrUint64     = "uint64"
-	StrSint32     = "sint32"
-	StrSint64     = "sint64"
-	StrI16        = "i16"
-	StrI32        = "i32"
-	StrI64        = "i64"
-	StrFixed32    = "fixed32"
-	StrFixed64    = "fixed64"
-	StrFloat32    = "float32"
-	StrFloat64    = "float64"
-	StrDouble     = "double"
-	StrFloat      = "float"
-	StrBool       = "bool"
-	StrString     = "string"
-	StrComplex64  = "complex64"
-	StrComplex128 = "complex128"
-	StrByte       = "byte"
-	StrBytes      = "bytes"
-	StrRune       = "rune"
-	StrUintptr    = "uintptr"
-	StrAny        = "any"
-	StrPbAny      = "google.protobuf.Any"
-	StrBinary     = "binary"
-	StrMap        = "map"
-	StrList       = "list"
-	StrSet        = "set"
-	StrNil        = "nil"
-	StrNull       = "null"
-	StrNumber     = "number"
-	StrRepeated   = "repeated"
+	StrInt               = "int"
+	StrInt8              = "int8"
+	StrInt16             = "int16"
+	StrInt32             = "int32"
+	StrInt64             = "int64"
+	StrUint              = "uint"
+	StrUint8             = "uint8"
+	StrUint16            = "uint16"
+	StrUint32            = "uint32"
+	StrUint64            = "uint64"
+	StrSint32            = "sint32"
+	StrSint64            = "sint64"
+	StrI16               = "i16"
+	StrI32               = "i32"
+	StrI64               = "i64"
+	StrFixed32           = "fixed32"
+	StrFixed64           = "fixed64"
+	StrFloat32           = "float32"
+	StrFloat64           = "float64"
+	StrDouble            = "double"
+	StrFloat             = "float"
+	StrBool              = "bool"
+	StrBoolean           = "boolean"
+	StrString            = "string"
+	StrComplex64         = "complex64"
+	StrComplex128        = "complex128"
+	StrByte              = "byte"
+	StrBytes             = "bytes"
+	StrRune              = "rune"
+	StrUintptr           = "uintptr"
+	StrAny               = "any"
+	StrPbAny             = "google.protobuf.Any"
+	StrTime              = "time.Time"
+	StrPbEmpty           = "google.protobuf.Empty"
+	StrVoid              = "void"
+	StrProto3            = "proto3"
+	StrGoPackage         = "go_package"
+	StrPbTimestamp       = "google.protobuf.Timestamp"
+	StrDuration          = "time.Duration"
+	StrPbDuration        = "google.protobuf.Duration"
+	StrPbStruct          = "google.protobuf.Struct"
+	StrPbValue           = "google.protobuf.Value"
+	StrPbListValue       = "google.protobuf.ListValue"
+	StrBinary            = "binary"
+	StrMap               = "map"
+	StrList              = "list"
+	StrSet               = "set"
+	StrNil               = "nil"
+	StrNull              = "null"
+	StrNumber            = "number"
+	StrRepeated          = "repeated"
+	StrI8                = "i8"
+	StrU8                = "u8"
+	StrU16               = "u16"
+	StrU32               = "u32"
+	StrU64               = "u64"
+	StrF32               = "f32"
+	StrF64               = "f64"
+	StrVec               = "Vec"
+	StrHashMap           = "HashMap"
+	StrHashSet           = "HashSet"
+	StrOption            = "Option"
+	StrBox               = "Box"
+	StrUpperString       = "String"
+	StrJavaObject        = "Object"
+	StrJavaBoolean       = "Boolean"
+	StrJavaFloat         = "Float"
+	StrJavaDouble        = "Double"
+	StrJavaByte          = "Byte"
+	StrJavaShort         = "Short"
+	StrJavaInteger       = "Integer"
+	StrJavaLong          = "Long"
+	StrJavaList          = "List"
+	StrJavaMap           = "Map"
+	StrJavaSet           = "Set"
+	StrKotlinInt         = "Int"
+	StrKotlinUByte       = "UByte"
+	StrKotlinUShort      = "UShort"
+	StrKotlinUInt        = "UInt"
+	StrKotlinULong       = "ULong"
+	StrKotlinByteArray   = "ByteArray"
+	StrKotlinJsonElement = "JsonElement"
+	StrRustValue         = "serde_json::Value"
 )
 
 var (
 	SourceLangs = []Lang{
+		{
+			// json schema should be matched before json, as `.schema.json`
+			// also has the `.json` suffix
+			Lang:    LangJsonSchema,
+			Aliases: []string{LangSchemaJson},
+		},
 		{
 			Lang: LangJson,
 		},
@@ -99,10 +179,34 @@ var (
 		{
 			Lang: LangThrift,
 		},
+		{
+			Lang:    LangTypeScript,
+			Aliases: []string{LangTs},
+		},
+		{
+			Lang:    LangJsonSchema,
+			Aliases: []string{LangSchemaJson},
+		},
+		{
+			Lang:    LangRust,
+			Aliases: []string{LangRs},
+		},
+		{
+			Lang: LangJava,
+		},
+		{
+			Lang:    LangKotlin,
+			Aliases: []string{LangKt},
+		},
 	}
 	LangTmplMap = map[string]string{
-		LangGo:     tmpl.Go,
-		LangProto:  tmpl.Proto,
-		LangThrift: tmpl.Thrift,
+		LangGo:         tmpl.Go,
+		LangProto:      tmpl.Proto,
+		LangThrift:     tmpl.Thrift,
+		LangTypeScript: tmpl.TypeScript,
+		LangJsonSchema: tmpl.JsonSchema,
+		LangRust:       tmpl.Rust,
+		LangJava:       tmpl.Java,
+		LangKotlin:     tmpl.Kotlin,
 	}
 )
@@ -13,6 +13,45 @@ type Context struct {
 	Prefix     string
 	Suffix     string
 	XMLContext XMLContext
+
+	// Merge merges multiple samples into one inferred schema, the
+	// samples come from a top level array, ndjson or multi-document yaml.
+	// The field which is absent or null in some samples becomes optional.
+	Merge bool
+
+	// DetectFormat detects the format of the string value, such as
+	// date time, base64, uuid and url
+	DetectFormat bool
+
+	// Package overrides the package of the source, it is used as the
+	// protobuf package, the thrift `*` namespace and the golang package name
+	Package string
+
+	// GoPackage overrides the golang import path of the source, it is used
+	// as the protobuf `go_package` option and the thrift `go` namespace
+	GoPackage string
+
+	// ImportPaths is the directories to search the imported protobuf and thrift files
+	ImportPaths []string
+
+	// Embed is the policy of the golang embedded struct, [EmbedFlatten]
+	// flattens its fields into the embedding struct, [EmbedReference]
+	// references it as a field. It is [EmbedFlatten] if empty.
+	Embed string
+
+	// Typedef keeps the golang named types which are not struct or enum as
+	// typedef, such as `type UserID int64`, otherwise they are resolved to
+	// their underlying types
+	Typedef bool
+
+	// TagKey is the key of the golang struct tag which names the fields,
+	// such as `json`, `protobuf` and `thrift`. It is [TagKeyDefault] if
+	// empty.
+	TagKey string
+
+	// GoTypeMappings extends or overrides the [DefaultGoTypeMappings] of the
+	// golang types from other packages
+	GoTypeMappings map[string]GoTypeMapping
 }
 
 func NewContext(src, dst, root, prefix, suffix string, xmlContext XMLContext) Context {
@@ -51,6 +51,19 @@ func (p CsvParser) Parse(reader io.Reader) ([]*Struct, error) {
 	return []*Struct{st}, nil
 }
 
+// ParseFile method parse csv source to [File], the root is the struct of a
+// row
+func (p CsvParser) ParseFile(reader io.Reader) (*File, error) {
+	structs, err := p.Parse(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &File{
+		Root:    structs[0].Type,
+		Structs: structs,
+	}, nil
+}
+
 func (p CsvParser) formatItem(str string) string {
 	str = strings.TrimSpace(str)
 	items := strings.Split(str, " ")
@@ -1,3 +1,3 @@
-// Package st2 provide a package to parse json/protobuf/thrift/go/csv code and
-// generage go/protobuf/thrift code
+// Package st2 provide a package to parse json/jsonschema/protobuf/thrift/go/csv code and
+// generage go/protobuf/thrift/typescript/jsonschema/rust/java/kotlin code
 package st2
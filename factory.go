@@ -9,6 +9,8 @@ func CreateParser(ctx Context) Parse {
 		return NewGoParser(ctx)
 	case LangJson:
 		return NewJsonParser(ctx)
+	case LangJsonSchema:
+		return NewJsonSchemaParser(ctx)
 	case LangYaml:
 		return NewYamlParser(ctx)
 	case LangProto:
@@ -36,6 +38,16 @@ func CreateTmpl(ctx Context) string {
 		return tmpl.Proto
 	case LangThrift:
 		return tmpl.Thrift
+	case LangTypeScript, LangTs:
+		return tmpl.TypeScript
+	case LangJsonSchema:
+		return tmpl.JsonSchema
+	case LangRust, LangRs:
+		return tmpl.Rust
+	case LangJava:
+		return tmpl.Java
+	case LangKotlin, LangKt:
+		return tmpl.Kotlin
 	}
 	return ""
 }
@@ -45,6 +57,8 @@ func CreateFormater(ctx Context) Format {
 	switch ctx.Dst {
 	case LangGo:
 		return &GoFormater{}
+	case LangJsonSchema:
+		return &JsonFormater{}
 	}
 	return &EmptyFormater{}
 }
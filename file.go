@@ -0,0 +1,417 @@
+package st2
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var protoWellKnownRegexp = regexp.MustCompile(`google\.protobuf\.(\w+)`)
+
+// protoWellKnownFiles map the well-known types to the files declare them
+var protoWellKnownFiles = map[string]string{
+	"Any":         "google/protobuf/any.proto",
+	"Duration":    "google/protobuf/duration.proto",
+	"Empty":       "google/protobuf/empty.proto",
+	"Timestamp":   "google/protobuf/timestamp.proto",
+	"Struct":      "google/protobuf/struct.proto",
+	"Value":       "google/protobuf/struct.proto",
+	"ListValue":   "google/protobuf/struct.proto",
+	"DoubleValue": "google/protobuf/wrappers.proto",
+	"FloatValue":  "google/protobuf/wrappers.proto",
+	"Int32Value":  "google/protobuf/wrappers.proto",
+	"Int64Value":  "google/protobuf/wrappers.proto",
+	"UInt32Value": "google/protobuf/wrappers.proto",
+	"UInt64Value": "google/protobuf/wrappers.proto",
+	"BoolValue":   "google/protobuf/wrappers.proto",
+	"StringValue": "google/protobuf/wrappers.proto",
+	"BytesValue":  "google/protobuf/wrappers.proto",
+}
+
+// Namespace is a thrift namespace declaration
+type Namespace struct {
+	Language string
+	Name     string
+}
+
+// Typedef is a typedef declaration, such as thrift `typedef i64 UserId`
+type Typedef struct {
+	Name    string
+	Type    Type
+	Comment Comment
+}
+
+// GoType get the golang underlying type of the typedef
+func (t Typedef) GoType() string {
+	return strings.TrimPrefix(t.Type.Go(), "*")
+}
+
+// File is a parsed result which contains all the declarations of a source
+// file
+type File struct {
+	// Syntax is the protobuf syntax, such as `proto3`
+	Syntax string
+	// Package is the package of the source, it comes from the protobuf
+	// package, the thrift `*` namespace or the golang package clause
+	Package string
+	// GoPackage is the import path of the golang package, it comes from the
+	// protobuf `go_package` option or the thrift `go` namespace
+	GoPackage string
+	// Namespaces is the thrift namespaces of the other languages
+	Namespaces []*Namespace
+	// Includes is the paths of the thrift included files, the types declared
+	// in them are referred by the qualified names, such as `shared.Base`
+	Includes []string
+	// IncludeGoPackages is the golang import paths of the thrift included
+	// files keyed by the include names, it comes from their `go` namespaces
+	IncludeGoPackages map[string]string
+	// Root is the type of the whole document of the sample sources, such as
+	// json and csv, it is nil for the sources declaring types only
+	Root Type
+
+	Typedefs  []*Typedef
+	Constants []*Constant
+	Structs   []*Struct
+	Services  []*Service
+}
+
+// GoConstants get the constants which can be declared as golang const
+func (f File) GoConstants() []*Constant {
+	res := make([]*Constant, 0)
+	for _, c := range f.Constants {
+		if c.IsGoConst() {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// GoVariables get the constants which should be declared as golang
+// variables, such as list and map
+func (f File) GoVariables() []*Constant {
+	res := make([]*Constant, 0)
+	for _, c := range f.Constants {
+		if !c.IsGoConst() {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// HasHeader reports whether there is any package information to generate a
+// file header
+func (f File) HasHeader() bool {
+	return f.Package != "" || f.GoPackage != "" || len(f.Namespaces) > 0 || len(f.Includes) > 0
+}
+
+// ProtoSyntax get the protobuf syntax, default is `proto3`
+func (f File) ProtoSyntax() string {
+	if f.Syntax == "" {
+		return StrProto3
+	}
+	return f.Syntax
+}
+
+// GoPackageName get the golang package name, it uses the name after `;` or
+// the last element of [File.GoPackage], then the last element of
+// [File.Package]
+func (f File) GoPackageName() string {
+	name := ""
+	switch {
+	case f.GoPackage != "":
+		name = f.GoPackage
+		if index := strings.LastIndex(name, ";"); index >= 0 {
+			name = name[index+1:]
+		} else {
+			name = path.Base(name)
+		}
+	case f.Package != "":
+		name = f.Package
+		if index := strings.LastIndex(name, "."); index >= 0 {
+			name = name[index+1:]
+		}
+	}
+	return strings.ToLower(normalizeToken(name, ""))
+}
+
+// GoImports get the golang packages imported by the structs and services
+func (f File) GoImports() []string {
+	set := make(map[string]bool)
+	if len(f.Services) > 0 {
+		set["context"] = true
+	}
+	for _, st := range f.Structs {
+		if st.usesGoTime() {
+			set["time"] = true
+		}
+		if t, ok := st.Type.(*StructLikeType); ok && t.IsException() {
+			set["fmt"] = true
+		}
+	}
+
+	imports := make([]string, 0, len(set))
+	for i := range set {
+		imports = append(imports, i)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// GoIncludes get the golang import specs of the thrift included files, the
+// package is imported with the include name when they are different
+func (f File) GoIncludes() []string {
+	includes := make([]string, 0, len(f.IncludeGoPackages))
+	for name, pkg := range f.IncludeGoPackages {
+		if path.Base(pkg) == name {
+			includes = append(includes, strconv.Quote(pkg))
+		} else {
+			includes = append(includes, name+" "+strconv.Quote(pkg))
+		}
+	}
+	sort.Strings(includes)
+	return includes
+}
+
+// ProtoImports get the files of the well-known types used by the structs and
+// services, and the files converted from the thrift included files, whose
+// packages are assumed to be the include names
+func (f File) ProtoImports() []string {
+	names := make([]string, 0)
+	for _, st := range f.Structs {
+		for _, m := range st.Members {
+			names = append(names, m.Proto())
+		}
+	}
+	for _, s := range f.Services {
+		for _, m := range s.Methods {
+			names = append(names, m.ProtoRequest(), m.ProtoResponse())
+		}
+	}
+
+	set := make(map[string]bool)
+	imports := make([]string, 0)
+	for _, name := range names {
+		for _, match := range protoWellKnownRegexp.FindAllStringSubmatch(name, -1) {
+			file, ok := protoWellKnownFiles[match[1]]
+			if !ok || set[file] {
+				continue
+			}
+			set[file] = true
+			imports = append(imports, file)
+		}
+	}
+	for _, include := range f.Includes {
+		file := strings.TrimSuffix(include, path.Ext(include)) + ".proto"
+		if !set[file] {
+			set[file] = true
+			imports = append(imports, file)
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// RustImports get the rust items used by the structs, the integer enum is
+// serialized by serde_repr
+func (f File) RustImports() []string {
+	set := make(map[string]bool)
+	collections := make([]string, 0, 2)
+	for _, st := range f.Structs {
+		if _, ok := st.Type.(*EnumType); ok && !st.StringEnum() {
+			set["serde_repr::{Deserialize_repr, Serialize_repr}"] = true
+		} else {
+			set["serde::{Deserialize, Serialize}"] = true
+		}
+		for _, m := range st.Members {
+			for _, name := range []string{StrHashMap, StrHashSet} {
+				if strings.Contains(m.Rust(), name+"<") && !slices.Contains(collections, name) {
+					collections = append(collections, name)
+				}
+			}
+		}
+	}
+
+	sort.Strings(collections)
+	switch len(collections) {
+	case 1:
+		set["std::collections::"+collections[0]] = true
+	case 2:
+		set["std::collections::{"+strings.Join(collections, ", ")+"}"] = true
+	}
+	return sortedKeys(set)
+}
+
+// JsonSchemaRoot get the keywords of the root schema, such as
+// `"$ref": "#/$defs/Root"`, it is empty if there is no root type
+func (f File) JsonSchemaRoot() string {
+	if f.Root == nil {
+		return ""
+	}
+	schema := f.Root.JsonSchema()
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(schema, "{"), "}"))
+}
+
+// RustStruct is a [Struct] rendered as rust, the members referencing the
+// structs in a cycle are boxed
+type RustStruct struct {
+	*Struct
+	Members []RustMember
+}
+
+// RustMember is a [Member] of [RustStruct]
+type RustMember struct {
+	*Member
+	// Box reports whether the struct of the member is boxed, the recursive
+	// struct has an infinite size without the indirection
+	Box bool
+}
+
+// Rust get the rust field type string, the boxed struct is wrapped in `Box`
+func (m RustMember) Rust() string {
+	if !m.Box {
+		return m.Member.Rust()
+	}
+	t := StrBox + "<" + m.Type.Rust() + ">"
+	if m.Optional {
+		return StrOption + "<" + t + ">"
+	}
+	return t
+}
+
+// RustStructs get the structs rendered as rust, the member is boxed if its
+// struct references the struct of the member directly or through the other
+// structs. The `Vec` and `HashMap` are already the indirection.
+func (f File) RustStructs() []RustStruct {
+	edges := make(map[string][]string)
+	for _, st := range f.Structs {
+		for _, m := range st.Members {
+			if name, ok := rustDirectStruct(m.Type); ok {
+				edges[st.Type.Rust()] = append(edges[st.Type.Rust()], name)
+			}
+		}
+	}
+
+	res := make([]RustStruct, 0, len(f.Structs))
+	for _, st := range f.Structs {
+		rs := RustStruct{
+			Struct:  st,
+			Members: make([]RustMember, 0, len(st.Members)),
+		}
+		for _, m := range st.Members {
+			name, ok := rustDirectStruct(m.Type)
+			rs.Members = append(rs.Members, RustMember{
+				Member: m,
+				Box:    ok && rustReaches(edges, name, st.Type.Rust(), make(map[string]bool)),
+			})
+		}
+		res = append(res, rs)
+	}
+	return res
+}
+
+// rustDirectStruct get the name of the struct type t, the typedef is
+// resolved to the type it defines
+func rustDirectStruct(t Type) (string, bool) {
+	if st, ok := underlyingType(t).(*StructLikeType); ok {
+		return st.Rust(), true
+	}
+	return "", false
+}
+
+// rustReaches reports whether the struct from references the struct to
+// directly or through the other structs
+func rustReaches(edges map[string][]string, from, to string, visited map[string]bool) bool {
+	if from == to {
+		return true
+	}
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+	for _, next := range edges[from] {
+		if rustReaches(edges, next, to, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// JavaImports get the java classes used by the structs, the integer enum is
+// serialized as its value by the jackson `@JsonValue`
+func (f File) JavaImports() []string {
+	set := make(map[string]bool)
+	for _, st := range f.Structs {
+		if _, ok := st.Type.(*EnumType); ok && !st.StringEnum() {
+			set["com.fasterxml.jackson.annotation.JsonValue"] = true
+		} else if len(st.Members) > 0 {
+			set["com.fasterxml.jackson.annotation.JsonProperty"] = true
+		}
+		for _, m := range st.Members {
+			for _, name := range []string{StrJavaList, StrJavaMap, StrJavaSet} {
+				if strings.Contains(m.Java(), name+"<") {
+					set["java.util."+name] = true
+				}
+			}
+		}
+	}
+	return sortedKeys(set)
+}
+
+// KotlinImports get the kotlin classes used by the structs, the integer
+// enum is serialized as its value by a custom serializer
+func (f File) KotlinImports() []string {
+	set := make(map[string]bool)
+	for _, st := range f.Structs {
+		set["kotlinx.serialization.Serializable"] = true
+		if _, ok := st.Type.(*EnumType); ok && !st.StringEnum() {
+			set["kotlinx.serialization.KSerializer"] = true
+			set["kotlinx.serialization.descriptors.PrimitiveKind"] = true
+			set["kotlinx.serialization.descriptors.PrimitiveSerialDescriptor"] = true
+			set["kotlinx.serialization.encoding.Decoder"] = true
+			set["kotlinx.serialization.encoding.Encoder"] = true
+		} else if len(st.Members) > 0 {
+			set["kotlinx.serialization.SerialName"] = true
+		}
+		for _, m := range st.Members {
+			if strings.Contains(m.Kotlin(), StrKotlinJsonElement) {
+				set["kotlinx.serialization.json.JsonElement"] = true
+			}
+		}
+	}
+	return sortedKeys(set)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ThriftNamespaces get the thrift namespaces, the `*` namespace comes from
+// [File.Package] and the `go` namespace comes from [File.GoPackage]
+func (f File) ThriftNamespaces() []*Namespace {
+	namespaces := make([]*Namespace, 0, len(f.Namespaces)+2)
+	if f.Package != "" {
+		namespaces = append(namespaces, &Namespace{
+			Language: "*",
+			Name:     f.Package,
+		})
+	}
+	if f.GoPackage != "" {
+		goPackage := f.GoPackage
+		if index := strings.Index(goPackage, ";"); index >= 0 {
+			goPackage = goPackage[:index]
+		}
+		namespaces = append(namespaces, &Namespace{
+			Language: LangGo,
+			Name:     strings.ReplaceAll(goPackage, "/", "."),
+		})
+	}
+	return append(namespaces, f.Namespaces...)
+}
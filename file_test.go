@@ -0,0 +1,119 @@
+package st2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFile_GoPackageName(t *testing.T) {
+	tests := []struct {
+		name string
+		init func(t *testing.T) File
+
+		want1 string
+	}{
+		{
+			name: "empty",
+			init: func(t *testing.T) File {
+				return File{}
+			},
+			want1: "",
+		},
+		{
+			name: "go package with name",
+			init: func(t *testing.T) File {
+				return File{
+					Package:   "foo.bar",
+					GoPackage: "github.com/tenfyzhong/st2;model",
+				}
+			},
+			want1: "model",
+		},
+		{
+			name: "go package",
+			init: func(t *testing.T) File {
+				return File{
+					GoPackage: "github.com/tenfyzhong/st2",
+				}
+			},
+			want1: "st2",
+		},
+		{
+			name: "package",
+			init: func(t *testing.T) File {
+				return File{
+					Package: "foo.bar.v1",
+				}
+			},
+			want1: "v1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := tt.init(t)
+			got1 := receiver.GoPackageName()
+
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("File.GoPackageName got1 = %v, want1: %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestFile_ThriftNamespaces(t *testing.T) {
+	tests := []struct {
+		name string
+		init func(t *testing.T) File
+
+		want1 []*Namespace
+	}{
+		{
+			name: "empty",
+			init: func(t *testing.T) File {
+				return File{}
+			},
+			want1: []*Namespace{},
+		},
+		{
+			name: "all",
+			init: func(t *testing.T) File {
+				return File{
+					Package:   "foo.bar",
+					GoPackage: "github.com/tenfyzhong/st2;model",
+					Namespaces: []*Namespace{
+						{
+							Language: "java",
+							Name:     "com.foo.bar",
+						},
+					},
+				}
+			},
+			want1: []*Namespace{
+				{
+					Language: "*",
+					Name:     "foo.bar",
+				},
+				{
+					Language: "go",
+					Name:     "github.com.tenfyzhong.st2",
+				},
+				{
+					Language: "java",
+					Name:     "com.foo.bar",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := tt.init(t)
+			got1 := receiver.ThriftNamespaces()
+
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("File.ThriftNamespaces got1 = %v, want1: %v", got1, tt.want1)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package st2
 
-import "go/format"
+import (
+	"bytes"
+	"encoding/json"
+	"go/format"
+)
 
 // Format is an interface to format source code
 type Format interface {
@@ -26,3 +30,17 @@ func (f GoFormater) Format(data []byte) []byte {
 	data, _ = format.Source(data)
 	return data
 }
+
+// JsonFormater is a struct implement the [Format] interface with indent json
+// source data
+type JsonFormater struct {
+}
+
+func (f JsonFormater) Format(data []byte) []byte {
+	buf := new(bytes.Buffer)
+	// fallback to the origin data if indent failed
+	if err := json.Indent(buf, data, "", "  "); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
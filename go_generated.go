@@ -0,0 +1,200 @@
+package st2
+
+import (
+	"go/ast"
+	"go/constant"
+	"strings"
+)
+
+// The conventions of the code generated by protoc-gen-go and Kitex, they are
+// recognised in the generated files only, see [ast.IsGenerated].
+
+// collectOneofWrapper collects the wrapper struct of the protobuf oneof by
+// its marker method, such as `func (*User_Email) isUser_Contact() {}`
+func (p GoParser) collectOneofWrapper(fn *ast.FuncDecl) {
+	if !p.generated || fn.Recv == nil || len(fn.Recv.List) != 1 ||
+		fn.Name.IsExported() || !strings.HasPrefix(fn.Name.Name, "is") ||
+		fn.Type.Params.NumFields() > 0 || fn.Type.Results.NumFields() > 0 {
+		return
+	}
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	name, ok := recv.(*ast.Ident)
+	if !ok {
+		return
+	}
+	p.decls.oneofs[fn.Name.Name] = append(p.decls.oneofs[fn.Name.Name], name.Name)
+	p.decls.wrappers[name.Name] = true
+}
+
+// oneofMember gets the member of the protobuf oneof field, such as
+// `Contact isUser_Contact `protobuf_oneof:"contact"“. The union is named
+// by the interface without the `is` prefix, it is the message name and the
+// oneof name joined by underscore as [ProtoParser] does, and its members are
+// the fields of the wrapper structs.
+func (p GoParser) oneofMember(field *ast.Field, tags goTags) (*Member, bool) {
+	oneof, ok := tags.Lookup(TagKeyProtobufOneof)
+	if !ok || !p.generated {
+		return nil, false
+	}
+	iface, ok := field.Type.(*ast.Ident)
+	if !ok || len(p.decls.oneofs[iface.Name]) == 0 {
+		return nil, false
+	}
+
+	name := strings.TrimPrefix(iface.Name, "is")
+	union := p.decls.instances[name]
+	if union == nil {
+		union = &Struct{
+			Type: &StructLikeType{
+				Name:   name,
+				Source: SLSUnion,
+			},
+			Comment: p.parseComment(oneofDoc(field.Doc), field.Comment),
+			Oneof:   true,
+		}
+		for _, wrapper := range p.decls.oneofs[iface.Name] {
+			gs := p.decls.structs[p.typeName(p.pkg, wrapper)]
+			if gs == nil {
+				continue
+			}
+			union.Members = gs.parser.fields2Members(gs.st, map[string]bool{wrapper: true}, union.Members)
+		}
+		p.numberMembers(name, union.Members)
+		for _, member := range union.Members {
+			member.Optional = true
+		}
+		p.decls.instances[name] = union
+		p.decls.order = append(p.decls.order, union)
+	}
+
+	// oneof has no field number, use the number of the first field in it
+	index := 0
+	if len(union.Members) > 0 {
+		index = union.Members[0].Index
+	}
+	return &Member{
+		Field: oneof,
+		Type: &StructLikeType{
+			Name: name,
+		},
+		Index:   index,
+		Comment: union.Comment,
+		Oneof:   union,
+	}, true
+}
+
+// oneofDoc drops the wrapper list protoc-gen-go appends to the comment of
+// the oneof field, it begins with `Types that are assignable to`
+func oneofDoc(doc *ast.CommentGroup) *ast.CommentGroup {
+	if doc == nil {
+		return nil
+	}
+	for i, comment := range doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if strings.HasPrefix(text, "Types that are ") {
+			if i == 0 {
+				return nil
+			}
+			return &ast.CommentGroup{List: doc.List[:i]}
+		}
+	}
+	return doc
+}
+
+// processEnumMap renames the enum members by the value maps of
+// protoc-gen-go, such as `Status_name` and `Status_value`, they have the
+// names in the proto file. The alias absent from the constants is appended.
+func (p GoParser) processEnumMap(decl *ast.GenDecl, consts *goConsts) {
+	if !p.generated {
+		return
+	}
+	for _, s := range decl.Specs {
+		v, ok := s.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for i, ident := range v.Names {
+			if i >= len(v.Values) {
+				break
+			}
+			lit, ok := v.Values[i].(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+
+			var (
+				typeName string
+				byName   bool
+			)
+			switch {
+			case strings.HasSuffix(ident.Name, "_name"):
+				typeName = strings.TrimSuffix(ident.Name, "_name")
+			case strings.HasSuffix(ident.Name, "_value"):
+				typeName, byName = strings.TrimSuffix(ident.Name, "_value"), true
+			default:
+				continue
+			}
+			st := consts.enums[p.typeName(p.pkg, typeName)]
+			if st == nil {
+				continue
+			}
+
+			names := make([]string, 0, len(lit.Elts))
+			numbers := make([]int, 0, len(lit.Elts))
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				nameExpr, numberExpr := kv.Value, kv.Key
+				if byName {
+					nameExpr, numberExpr = kv.Key, kv.Value
+				}
+				name := p.evalConst(nameExpr, 0, consts)
+				number := p.evalConst(numberExpr, 0, consts)
+				if name.Kind() != constant.String || number.Kind() != constant.Int {
+					continue
+				}
+				n, ok := constant.Int64Val(number)
+				if !ok {
+					continue
+				}
+				names = append(names, constant.StringVal(name))
+				numbers = append(numbers, int(n))
+			}
+			renameEnumMembers(st, names, numbers)
+		}
+	}
+}
+
+// renameEnumMembers renames the first member of each number which is not
+// renamed yet, the name without member is appended
+func renameEnumMembers(st *Struct, names []string, numbers []int) {
+	renamed := make(map[string]bool, len(names))
+	for _, name := range names {
+		renamed[name] = true
+	}
+
+next:
+	for i, name := range names {
+		for _, member := range st.Members {
+			if member.Field == name {
+				continue next
+			}
+		}
+		for _, member := range st.Members {
+			if member.Index == numbers[i] && !renamed[member.Field] {
+				member.Field = name
+				continue next
+			}
+		}
+		st.Members = append(st.Members, &Member{
+			Field: name,
+			Type:  st.Type,
+			Index: numbers[i],
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package st2
+
+import (
+	"go/ast"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GoTypeMapping is the mapping of a golang type declared in other package,
+// such as `time.Time`, to [Type]
+type GoTypeMapping struct {
+	Type Type
+	// Optional reports whether the type is nullable, such as
+	// `sql.NullString`, the member of it is optional
+	Optional bool
+}
+
+// DefaultGoTypeMappings is the built-in mappings of the golang types from
+// the standard library and the popular third-party packages, the key is the
+// import path and the type name joined by dot. It can be extended or
+// overridden by [Context.GoTypeMappings].
+var DefaultGoTypeMappings = map[string]GoTypeMapping{
+	"time.Time":                             {Type: TimestampVal},
+	"time.Duration":                         {Type: DurationVal},
+	"encoding/json.RawMessage":              {Type: AnyVal},
+	"encoding/json.Number":                  {Type: StringVal},
+	"database/sql.NullString":               {Type: StringVal, Optional: true},
+	"database/sql.NullInt64":                {Type: Int64Val, Optional: true},
+	"database/sql.NullInt32":                {Type: Int32Val, Optional: true},
+	"database/sql.NullInt16":                {Type: Int16Val, Optional: true},
+	"database/sql.NullByte":                 {Type: Uint8Val, Optional: true},
+	"database/sql.NullFloat64":              {Type: Float64Val, Optional: true},
+	"database/sql.NullBool":                 {Type: BoolVal, Optional: true},
+	"database/sql.NullTime":                 {Type: TimestampVal, Optional: true},
+	"github.com/google/uuid.UUID":           {Type: StringVal},
+	"github.com/gofrs/uuid.UUID":            {Type: StringVal},
+	"github.com/satori/go.uuid.UUID":        {Type: StringVal},
+	"github.com/shopspring/decimal.Decimal": {Type: StringVal},
+	"google.golang.org/protobuf/types/known/timestamppb.Timestamp": {Type: TimestampVal},
+	"google.golang.org/protobuf/types/known/durationpb.Duration":   {Type: DurationVal},
+	"google.golang.org/protobuf/types/known/anypb.Any":             {Type: AnyVal},
+}
+
+var goMajorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// selectorMapping gets the mapping of the qualified type, such as
+// `time.Time`. If the import declaration of the package is absent, such as
+// a snippet, the package name matches the last element of the import path.
+func (p GoParser) selectorMapping(sel *ast.SelectorExpr) (GoTypeMapping, bool) {
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return GoTypeMapping{}, false
+	}
+
+	mappings := p.goTypeMappings()
+	if importPath, ok := p.importPaths[x.Name]; ok {
+		m, ok := mappings[importPath+"."+sel.Sel.Name]
+		return m, ok
+	}
+
+	// sort the keys to get the same mapping for the same package name
+	keys := make([]string, 0, len(mappings))
+	for key := range mappings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		index := strings.LastIndex(key, ".")
+		if key[index+1:] == sel.Sel.Name && goPackageName(key[:index]) == x.Name {
+			return mappings[key], true
+		}
+	}
+	return GoTypeMapping{}, false
+}
+
+func (p GoParser) goTypeMappings() map[string]GoTypeMapping {
+	if len(p.ctx.GoTypeMappings) == 0 {
+		return DefaultGoTypeMappings
+	}
+	mappings := make(map[string]GoTypeMapping, len(DefaultGoTypeMappings)+len(p.ctx.GoTypeMappings))
+	for key, m := range DefaultGoTypeMappings {
+		mappings[key] = m
+	}
+	for key, m := range p.ctx.GoTypeMappings {
+		mappings[key] = m
+	}
+	return mappings
+}
+
+// goPackageName gets the default package name of the import path, it is the
+// last element of the path without the version suffix, such as `yaml` of
+// `gopkg.in/yaml.v3` and `uuid` of `github.com/satori/go.uuid`
+func goPackageName(importPath string) string {
+	name := path.Base(importPath)
+	if goMajorVersion.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	name = strings.TrimPrefix(name, "go.")
+	name = strings.TrimPrefix(name, "go-")
+	if index := strings.Index(name, "."); index >= 0 {
+		name = name[:index]
+	}
+	return name
+}
@@ -0,0 +1,237 @@
+package st2
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// goPackage is a golang package loaded from a directory
+type goPackage struct {
+	// Path is the import path, it is empty if the directory is not in a
+	// golang module
+	Path  string
+	Name  string
+	Fset  *token.FileSet
+	Files []*ast.File
+}
+
+// goTypeCollisions gets the exported type names declared in more than one
+// package, they are prefixed by the package name to be told apart
+func goTypeCollisions(pkgs []*goPackage) map[string]bool {
+	declared := make(map[string]*goPackage)
+	collisions := make(map[string]bool)
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, node := range f.Decls {
+				decl, ok := node.(*ast.GenDecl)
+				if !ok || decl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok || !ts.Name.IsExported() {
+						continue
+					}
+					if other := declared[ts.Name.Name]; other != nil && other != pkg {
+						collisions[ts.Name.Name] = true
+					}
+					declared[ts.Name.Name] = pkg
+				}
+			}
+		}
+	}
+	return collisions
+}
+
+// goImporter type checks the loaded golang packages, a package imports the
+// others by the import path. The packages not loaded can not be imported,
+// the types from them are invalid.
+type goImporter struct {
+	pkgs    map[string]*goPackage
+	checked map[*goPackage]*types.Package
+}
+
+func newGoImporter(pkgs []*goPackage) *goImporter {
+	i := &goImporter{
+		pkgs:    make(map[string]*goPackage),
+		checked: make(map[*goPackage]*types.Package),
+	}
+	for _, pkg := range pkgs {
+		if pkg.Path != "" {
+			i.pkgs[pkg.Path] = pkg
+		}
+	}
+	return i
+}
+
+// Import implements [types.Importer]
+func (i *goImporter) Import(path string) (*types.Package, error) {
+	pkg := i.pkgs[path]
+	if pkg == nil {
+		return nil, fmt.Errorf("package %s is not loaded", path)
+	}
+	if checked, ok := i.checked[pkg]; ok && checked == nil {
+		return nil, fmt.Errorf("import cycle of package %s", path)
+	}
+	return i.check(pkg), nil
+}
+
+// check type checks the package, the errors are ignored since the types
+// from the packages not loaded are always invalid
+func (i *goImporter) check(pkg *goPackage) *types.Package {
+	if checked := i.checked[pkg]; checked != nil {
+		return checked
+	}
+	// mark the package is being checked
+	i.checked[pkg] = nil
+
+	path := pkg.Path
+	if path == "" {
+		path = pkg.Name
+	}
+	conf := types.Config{
+		Importer: i,
+		Error:    func(error) {},
+	}
+	checked, _ := conf.Check(path, pkg.Fset, pkg.Files, nil)
+	i.checked[pkg] = checked
+	return checked
+}
+
+// loadGoPackages loads the golang packages matched by the pattern in the
+// lexical order of the directories, the pattern is a directory, or a
+// directory followed by `/...` to match all the sub directories. The
+// testdata, vendor and the hidden directories are skipped as go tool does.
+func loadGoPackages(pattern string) ([]*goPackage, error) {
+	root, recursive := strings.CutSuffix(filepath.ToSlash(pattern), "/...")
+	if root == "" {
+		root = "."
+	}
+	root = filepath.FromSlash(root)
+
+	dirs := []string{root}
+	if recursive {
+		dirs = dirs[:0]
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			name := d.Name()
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fset := token.NewFileSet()
+	pkgs := make([]*goPackage, 0, len(dirs))
+	for _, dir := range dirs {
+		pkg, err := loadGoPackage(fset, dir)
+		if err != nil {
+			return nil, err
+		}
+		if pkg != nil {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+// loadGoPackage loads the golang files of the directory, the test files and
+// the files excluded by the build constraints are skipped. It returns nil
+// if there is no golang file.
+func loadGoPackage(fset *token.FileSet, dir string) (*goPackage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &goPackage{
+		Path: goImportPath(dir),
+		Fset: fset,
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		match, err := build.Default.MatchFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Name = f.Name.Name
+		pkg.Files = append(pkg.Files, f)
+	}
+
+	if len(pkg.Files) == 0 {
+		return nil, nil
+	}
+	return pkg, nil
+}
+
+// goImportPath gets the import path of the directory from the go.mod of the
+// module containing it, it returns empty if it is not in a module
+func goImportPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		if module := goModulePath(filepath.Join(root, "go.mod")); module != "" {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil {
+				return ""
+			}
+			if rel == "." {
+				return module
+			}
+			return module + "/" + filepath.ToSlash(rel)
+		}
+		if filepath.Dir(root) == root {
+			return ""
+		}
+	}
+}
+
+// goModulePath gets the module path declared in the go.mod file
+func goModulePath(gomod string) string {
+	file, err := os.Open(gomod)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], "\"`")
+		}
+	}
+	return ""
+}
@@ -1,9 +1,12 @@
 package st2
 
 import (
+	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"io"
 	"strconv"
 	"strings"
@@ -12,6 +15,21 @@ import (
 // GoParser is a Parser to parse golang source.
 type GoParser struct {
 	ctx Context
+	// pkg is the type checked package of the file
+	pkg *types.Package
+	// imports is the imported packages which are parsed together with the
+	// file by the name, their types are referenced without the package name
+	imports map[string]*types.Package
+	// importPaths is the import paths of the file by the name
+	importPaths map[string]string
+	// decls is the declarations shared by the parsers of the files
+	decls *goDecls
+	// params is the type arguments by the type parameter names of the
+	// generic struct being instantiated
+	params map[string]Type
+	// generated reports whether the file is generated, such as by
+	// protoc-gen-go and Kitex, see [ast.IsGenerated]
+	generated bool
 }
 
 // NewGoParser create [GoParser]
@@ -23,178 +41,807 @@ func NewGoParser(ctx Context) *GoParser {
 
 // Parse method parse golang source
 func (p GoParser) Parse(reader io.Reader) ([]*Struct, error) {
+	file, err := p.ParseFile(reader)
+	if err != nil {
+		return nil, err
+	}
+	return file.Structs, nil
+}
+
+// ParseFile method parse golang source to [File], it contains the package
+func (p GoParser) ParseFile(reader io.Reader) (*File, error) {
 	fset := token.NewFileSet()
 	f, err := parser.ParseFile(fset, "", reader, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
-	ast.FileExports(f)
+	return p.parsePackages([]*goPackage{
+		{
+			Name:  f.Name.Name,
+			Fset:  fset,
+			Files: []*ast.File{f},
+		},
+	})
+}
+
+// ParsePackage method parse the golang packages matched by the pattern to a
+// [File], the pattern is a directory, or a directory followed by `/...` to
+// match all the sub directories. The types are consolidated in the
+// declaration order, and the types referenced across the packages are
+// resolved without the package name. The type name declared in more than one
+// package is prefixed by the package name, such as `modelUser` and
+// `apiUser`.
+func (p GoParser) ParsePackage(pattern string) (*File, error) {
+	pkgs, err := loadGoPackages(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no go files matched %s", pattern)
+	}
+	return p.parsePackages(pkgs)
+}
+
+// goStruct is a golang struct type with the parser of the file declaring it
+type goStruct struct {
+	parser  GoParser
+	st      *ast.StructType
+	comment Comment
+	// params is the type parameter names of the generic struct
+	params []string
+}
+
+// parserWith gets the parser of the struct fields, the type parameters are
+// substituted by the type arguments args
+func (gs *goStruct) parserWith(args []Type) GoParser {
+	parser := gs.parser
+	if len(gs.params) > 0 {
+		parser.params = make(map[string]Type, len(gs.params))
+		for i, name := range gs.params {
+			parser.params[name] = args[i]
+		}
+	}
+	return parser
+}
+
+// goDecls is the declarations shared by the parsers of the files
+type goDecls struct {
+	// structs is the struct types by the name, the embedded struct may be
+	// declared after the struct embedding it
+	structs map[string]*goStruct
+	// instances is the instantiated generic structs and the protobuf oneof
+	// unions by the name, they are emitted after the declared structs in the
+	// order of the use
+	instances map[string]*Struct
+	order     []*Struct
+	// oneofs is the wrapper struct names of the protobuf oneof by the name
+	// of the interface they implement in the generated files
+	oneofs map[string][]string
+	// wrappers is the wrapper structs of the protobuf oneof, they are
+	// members of the unions instead of the structs
+	wrappers map[string]bool
+	// collisions is the exported type names declared in more than one
+	// package
+	collisions map[string]bool
+	// err is the first error found in the declarations, such as the field
+	// number used by more than one field
+	err error
+}
+
+func (p GoParser) parsePackages(pkgs []*goPackage) (*File, error) {
+	importer := newGoImporter(pkgs)
+	decls := &goDecls{
+		structs:    make(map[string]*goStruct),
+		instances:  make(map[string]*Struct),
+		oneofs:     make(map[string][]string),
+		wrappers:   make(map[string]bool),
+		collisions: goTypeCollisions(pkgs),
+	}
+
+	// collect all the struct types first
+	parsers := make(map[*ast.File]GoParser)
+	for _, pkg := range pkgs {
+		checked := importer.check(pkg)
+		for _, f := range pkg.Files {
+			fp := p
+			fp.pkg = checked
+			fp.imports = p.importedPackages(f, importer)
+			fp.importPaths = p.importPathsOf(f)
+			fp.decls = decls
+			fp.generated = ast.IsGenerated(f)
+			parsers[f] = fp
+
+			for _, node := range f.Decls {
+				if fn, ok := node.(*ast.FuncDecl); ok {
+					fp.collectOneofWrapper(fn)
+					continue
+				}
+				decl, ok := node.(*ast.GenDecl)
+				if !ok || decl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					st, ok := ts.Type.(*ast.StructType)
+					name := fp.typeName(checked, ts.Name.Name)
+					if !ok || decls.structs[name] != nil {
+						continue
+					}
+					doc := ts.Doc
+					if doc == nil && len(decl.Specs) == 1 {
+						doc = decl.Doc
+					}
+					decls.structs[name] = &goStruct{
+						parser:  fp,
+						st:      st,
+						comment: fp.parseComment(doc, nil),
+						params:  p.typeParams(ts.TypeParams),
+					}
+				}
+			}
+		}
+	}
 
 	res := make([]*Struct, 0)
-	for _, node := range f.Decls {
-		st := p.processNode(fset, node)
-		if st != nil {
-			res = append(res, st...)
+	var typedefs []*Typedef
+	consts := newGoConsts()
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			for _, node := range f.Decls {
+				st := parsers[f].processNode(node, consts)
+				if st != nil {
+					res = append(res, st...)
+				}
+				if p.ctx.Typedef {
+					typedefs = append(typedefs, parsers[f].processTypedef(node)...)
+				}
+			}
+		}
+	}
+	res = append(res, decls.order...)
+	if decls.err != nil {
+		return nil, decls.err
+	}
+
+	return &File{
+		Package:  pkgs[0].Name,
+		Typedefs: typedefs,
+		Structs:  res,
+	}, nil
+}
+
+// importedPackages gets the packages imported by the file which are parsed
+// together, by the name referencing them in the file
+func (p GoParser) importedPackages(f *ast.File, importer *goImporter) map[string]*types.Package {
+	imports := make(map[string]*types.Package)
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		pkg, err := importer.Import(path)
+		if err != nil || pkg == nil {
+			continue
+		}
+		name := pkg.Name()
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = pkg
+	}
+	return imports
+}
+
+// importPathsOf gets the import paths of the file by the name referencing
+// them in the file
+func (p GoParser) importPathsOf(f *ast.File) map[string]string {
+	paths := make(map[string]string)
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := goPackageName(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		paths[name] = path
+	}
+	return paths
+}
+
+// processTypedef gets the typedefs of the named types which are not struct
+// or enum in the type declaration
+func (p GoParser) processTypedef(node ast.Decl) []*Typedef {
+	decl, ok := node.(*ast.GenDecl)
+	if !ok || decl.Tok != token.TYPE || p.pkg == nil {
+		return nil
+	}
+
+	res := make([]*Typedef, 0)
+	for _, s := range decl.Specs {
+		spec, ok := s.(*ast.TypeSpec)
+		if !ok || !spec.Name.IsExported() {
+			continue
+		}
+		tn, ok := p.pkg.Scope().Lookup(spec.Name.Name).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		typedef, ok := p.goType2Type(tn.Type()).(*TypedefType)
+		if !ok {
+			continue
+		}
+
+		doc := spec.Doc
+		if doc == nil && len(decl.Specs) == 1 {
+			doc = decl.Doc
+		}
+		res = append(res, &Typedef{
+			Name:    typedef.Name,
+			Type:    typedef.Type,
+			Comment: p.parseComment(doc, spec.Comment),
+		})
+	}
+	return res
+}
+
+// typeParams gets the names of the type parameters
+func (p GoParser) typeParams(params *ast.FieldList) []string {
+	if params == nil {
+		return nil
+	}
+	names := make([]string, 0, params.NumFields())
+	for _, field := range params.List {
+		for _, name := range field.Names {
+			names = append(names, name.Name)
 		}
 	}
-	return res, nil
+	return names
 }
 
-func (p GoParser) processNode(fset *token.FileSet, node ast.Decl) []*Struct {
+func (p GoParser) processNode(node ast.Decl, consts *goConsts) []*Struct {
 	switch n := node.(type) {
 	case *ast.GenDecl:
 		if n.Tok == token.TYPE {
-			st := p.processType(n)
-			if st == nil {
-				return nil
-			}
-			return []*Struct{st}
+			return p.processType(n)
 		} else if n.Tok == token.CONST {
-			return p.processConst(n)
+			return p.processConst(n, consts)
+		} else if n.Tok == token.VAR {
+			p.processEnumMap(n, consts)
 		}
 	}
 	return nil
 }
 
-func (p GoParser) processType(decl *ast.GenDecl) *Struct {
-	if len(decl.Specs) == 0 {
-		return nil
-	}
-	spec, ok := decl.Specs[0].(*ast.TypeSpec)
-	if !ok {
-		return nil
-	}
-	name := spec.Name.Name
-	st, ok := spec.Type.(*ast.StructType)
-	if !ok {
-		return nil
+// There are may be many types in a grouped type declaration. The generic
+// struct is emitted when it is instantiated.
+func (p GoParser) processType(decl *ast.GenDecl) []*Struct {
+	res := make([]*Struct, 0, len(decl.Specs))
+	for _, s := range decl.Specs {
+		spec, ok := s.(*ast.TypeSpec)
+		if !ok || !spec.Name.IsExported() || spec.TypeParams != nil || p.decls.wrappers[spec.Name.Name] {
+			continue
+		}
+
+		name := p.typeName(p.pkg, spec.Name.Name)
+		var members []*Member
+		switch t := spec.Type.(type) {
+		case *ast.StructType:
+			members = p.fields2Members(t, map[string]bool{name: true}, nil)
+		case *ast.IndexExpr, *ast.IndexListExpr:
+			// the type defined by an instantiated generic struct, such as
+			// `type UserPage Page[User]`, the alias is resolved to the
+			// instantiated struct
+			if spec.Assign.IsValid() {
+				continue
+			}
+			_, gs, args, ok := p.genericStruct(t)
+			if !ok {
+				continue
+			}
+			members = gs.parserWith(args).fields2Members(gs.st, map[string]bool{name: true}, nil)
+		default:
+			continue
+		}
+		p.numberMembers(name, members)
+
+		doc := spec.Doc
+		if doc == nil && len(decl.Specs) == 1 {
+			doc = decl.Doc
+		}
+
+		res = append(res, &Struct{
+			Type: &StructLikeType{
+				Name:   name,
+				Source: SLSStruct,
+			},
+			Members: members,
+			Comment: p.parseComment(doc, nil),
+		})
 	}
+	return res
+}
 
-	res := &Struct{
-		Type: &StructLikeType{
-			Name:   name,
-			Source: SLSStruct,
-		},
-		Comment: p.parseComment(decl.Doc, nil),
+// fields2Members appends the fields of st to members, the fields with
+// multiple names are expanded to multiple members, and the embedded fields
+// are flattened or referenced according to the [Context.Embed] policy.
+// The visiting contains the structs being flattened to avoid the cycle.
+func (p GoParser) fields2Members(st *ast.StructType, visiting map[string]bool, members []*Member) []*Member {
+	if st.Fields == nil {
+		return members
 	}
+	for _, field := range st.Fields.List {
+		tags := parseGoTag(field.Tag)
+		tagField := p.tagField(tags)
+		if tagField.Ignored {
+			continue
+		}
+		if oneof, ok := p.oneofMember(field, tags); ok {
+			members = append(members, oneof)
+			continue
+		}
 
-	if st.Fields != nil {
-		for i, field := range st.Fields.List {
-			if len(field.Names) == 0 {
+		if len(field.Names) == 0 {
+			if name, parser, ok := p.embeddedStruct(field, tagField, visiting); ok {
+				visiting[name] = true
+				// the fields are parsed in the file declaring the embedded struct
+				members = parser.fields2Members(p.decls.structs[name].st, visiting, members)
+				delete(visiting, name)
 				continue
 			}
-			fieldName := field.Names[0].Name
+		}
+
+		t := p.type2Type(field.Type)
+		if t == nil {
+			continue
+		}
 
-			t := p.type2Type(field.Type)
+		names := make([]string, 0, len(field.Names))
+		for _, name := range field.Names {
+			// the internal field of the generated code, such as
+			// XXX_unrecognized
+			if name.IsExported() && !(p.generated && strings.HasPrefix(name.Name, "XXX_")) {
+				names = append(names, name.Name)
+			}
+		}
+		if len(field.Names) == 0 {
+			// the embedded field is named by its type
+			if name := p.embeddedName(field.Type); ast.IsExported(name) {
+				names = append(names, name)
+			}
+		}
+
+		optional := p.isOptional(field.Type) || tagField.Optional
+		presence := false
+		if generated, ok := tags.optional(p.tagKey()); ok && p.generated {
+			// the optional-ness of the generated code is in its tag, the
+			// json tag of it is always omitempty
+			optional, presence = generated, generated
+		}
+
+		index, tagIndex := tags.index()
+		if !tagIndex || len(names) > 1 {
+			// the field without number is numbered by numberMembers after
+			// the numbers of all the fields are known
+			index = 0
+		}
+		for _, name := range names {
 			member := &Member{
-				Field:    snake(fieldName),
+				Field:    snake(name),
 				Type:     t,
-				Index:    i + 1,
-				Optional: p.isOptional(field.Type),
+				Index:    index,
+				Optional: optional,
 				Comment:  p.parseComment(field.Doc, field.Comment),
+				GoTag:    tags.Strings(),
+				Presence: presence,
 			}
-			if tag := p.tag2GoTag(field.Tag); tag != "" {
-				// if there any tag, use the first tag field name as the Member.Field value
-				member.Field = tag
+			if tagField.Name != "" {
+				// the field is named by the authoritative tag key
+				member.Field = tagField.Name
 			}
-			res.Members = append(res.Members, member)
+			members = append(members, member)
 		}
 	}
-	return res
+	return members
 }
 
-func (p GoParser) tag2GoTag(tag *ast.BasicLit) string {
-	if tag == nil {
-		return ""
+// numberMembers numbers the members without number into the numbers not
+// used by the numbered ones in order. The field number used by more than one
+// member of the struct name is recorded as the error.
+func (p GoParser) numberMembers(name string, members []*Member) {
+	used := make(map[int]string, len(members))
+	for _, member := range members {
+		if member.Index == 0 {
+			continue
+		}
+		if field, ok := used[member.Index]; ok && p.decls.err == nil {
+			p.decls.err = fmt.Errorf("field %s and %s of %s have the same number %d", field, member.Field, name, member.Index)
+		}
+		used[member.Index] = member.Field
 	}
 
-	// "`json:\"hello_world,omitempty\" proto:\"hello_world\"`"
-	item := strings.Trim(tag.Value, "`") // "json:\"hello_world,omitempty\" proto:\"hello_world\""
-	item = strings.Split(item, " ")[0]   // "json:\"hello_world,omitempty\""
-	items := strings.Split(item, ":")    // ["json:", "\"hello_world,omitempty\""]
-	if len(items) < 2 {
-		return ""
+	index := 1
+	for _, member := range members {
+		if member.Index != 0 {
+			continue
+		}
+		for {
+			if _, ok := used[index]; !ok {
+				break
+			}
+			index++
+		}
+		member.Index = index
+		used[index] = member.Field
 	}
-	item = items[1]                    // "\"hello_world,omitempty\""
-	item = strings.TrimSpace(item)     // "\"hello_world,omitempty\""
-	item = strings.Trim(item, "\"")    // "hello_world,omitempty"
-	item = strings.Split(item, ",")[0] // "hello_world"
-	return item
 }
 
-func (p GoParser) isOptional(field ast.Expr) bool {
-	if f, ok := field.(*ast.StarExpr); ok {
-		t := p.type2Type(f.X)
-		if t == nil {
-			return false
-		}
-		if p.isBasicType(t.Go()) {
-			return true
+// embeddedStruct returns the name of the struct to flatten into the
+// embedding struct and the parser of its fields, it returns false if the
+// embedded field should be referenced. A tagged embedded field is always
+// referenced as encoding/json does, and so is the struct declared outside
+// of the parsed packages.
+func (p GoParser) embeddedStruct(field *ast.Field, tagField goTagField, visiting map[string]bool) (string, GoParser, bool) {
+	if p.ctx.Embed == EmbedReference || tagField.Name != "" {
+		return "", p, false
+	}
+	typ := field.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch t := typ.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		name, gs, args, ok := p.genericStruct(t)
+		if !ok || visiting[name] {
+			return "", p, false
 		}
+		return name, gs.parserWith(args), true
 	}
-	return false
+	name, ok := p.localName(typ)
+	if !ok || p.decls.structs[name] == nil || visiting[name] || len(p.decls.structs[name].params) > 0 {
+		return "", p, false
+	}
+	return name, p.decls.structs[name].parser, true
 }
 
-// There are may be many enum in a const block
-func (p GoParser) processConst(decl *ast.GenDecl) []*Struct {
-	m := make(map[string]*Struct)
-	for _, spec := range decl.Specs {
-		v, ok := spec.(*ast.ValueSpec)
-		if !ok {
-			continue
+// embeddedName gets the field name of the embedded type, such as `Base` of
+// `*pkg.Base[T]`
+func (p GoParser) embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return p.embeddedName(t.X)
+	case *ast.IndexExpr:
+		return p.embeddedName(t.X)
+	case *ast.IndexListExpr:
+		return p.embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// localName gets the name of the type declared in the parsed packages, the
+// package name is omitted unless the name is declared in more than one
+// package
+func (p GoParser) localName(expr ast.Expr) (string, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return p.typeName(p.pkg, t.Name), true
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok && p.imports[x.Name] != nil {
+			return p.typeName(p.imports[x.Name], t.Sel.Name), true
 		}
+	}
+	return "", false
+}
 
-		if len(v.Names) == 0 {
-			continue
+// typeName gets the name of the type declared in the package pkg, the name
+// declared in more than one package is prefixed by the package name, such as
+// `modelUser`
+func (p GoParser) typeName(pkg *types.Package, name string) string {
+	if pkg == nil || p.decls == nil || !p.decls.collisions[name] {
+		return name
+	}
+	return pkg.Name() + name
+}
+
+// genericStruct gets the name, the generic struct and the type arguments of
+// the instantiation expression, such as `Page[User]`
+func (p GoParser) genericStruct(expr ast.Expr) (string, *goStruct, []Type, bool) {
+	x, args, ok := p.typeArgs(expr)
+	if !ok {
+		return "", nil, nil, false
+	}
+	name, ok := p.localName(x)
+	if !ok {
+		return "", nil, nil, false
+	}
+	gs := p.decls.structs[name]
+	if gs == nil || len(gs.params) != len(args) {
+		return "", nil, nil, false
+	}
+	return name, gs, args, true
+}
+
+// typeArgs gets the generic type and the type arguments of the
+// instantiation expression
+func (p GoParser) typeArgs(expr ast.Expr) (ast.Expr, []Type, bool) {
+	var (
+		x       ast.Expr
+		indices []ast.Expr
+	)
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		x, indices = t.X, []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		x, indices = t.X, t.Indices
+	default:
+		return nil, nil, false
+	}
+
+	args := make([]Type, 0, len(indices))
+	for _, index := range indices {
+		arg := p.type2Type(index)
+		if arg == nil {
+			return nil, nil, false
 		}
-		name := v.Names[0].Name
+		args = append(args, arg)
+	}
+	return x, args, true
+}
 
-		typeIdent, ok := v.Type.(*ast.Ident)
+// instantiate gets the type of the generic struct instantiated with the type
+// arguments, such as `Page[User]` is `PageUser`. The instantiated struct is
+// emitted once.
+func (p GoParser) instantiate(name string, args []Type) Type {
+	instance := name
+	for _, arg := range args {
+		instance += goTypeArgName(arg)
+	}
+	t := &StructLikeType{
+		Name: instance,
+	}
+
+	gs := p.decls.structs[name]
+	if p.decls.instances[instance] != nil || gs == nil || len(gs.params) != len(args) {
+		return t
+	}
+
+	st := &Struct{
+		Type: &StructLikeType{
+			Name:   instance,
+			Source: SLSStruct,
+		},
+		Comment: gs.comment,
+	}
+	// register it before parsing the members for the recursive struct
+	p.decls.instances[instance] = st
+	p.decls.order = append(p.decls.order, st)
+	st.Members = gs.parserWith(args).fields2Members(gs.st, map[string]bool{name: true}, nil)
+	p.numberMembers(instance, st.Members)
+	return t
+}
+
+// goTypeArgName gets the name of the type argument in the name of the
+// instantiated struct, such as `User` of `*User` and `Int64List` of `[]int64`
+func goTypeArgName(t Type) string {
+	switch t := t.(type) {
+	case *ArrayType:
+		return goTypeArgName(t.ChildType) + "List"
+	case *SetType:
+		return goTypeArgName(t.Key) + "Set"
+	case *MapType:
+		return goTypeArgName(t.Key) + goTypeArgName(t.Value) + "Map"
+	}
+	name := strings.TrimLeft(t.Go(), "*")
+	return camel(name[strings.LastIndex(name, ".")+1:])
+}
+
+func (p GoParser) isOptional(field ast.Expr) bool {
+	switch f := field.(type) {
+	case *ast.StarExpr:
+		t := p.type2Type(f.X)
+		return t != nil && t.IsBasicType()
+	case *ast.SelectorExpr:
+		// the nullable type, such as sql.NullString
+		m, ok := p.selectorMapping(f)
+		return ok && m.Optional
+	}
+	return false
+}
+
+// goConsts is the constants evaluated in a golang file
+type goConsts struct {
+	values map[string]constant.Value
+	// types is the type name of the typed constants
+	types map[string]string
+	// enums is the enums by the type name, an enum may be declared in
+	// multiple const blocks
+	enums map[string]*Struct
+}
+
+func newGoConsts() *goConsts {
+	return &goConsts{
+		values: make(map[string]constant.Value),
+		types:  make(map[string]string),
+		enums:  make(map[string]*Struct),
+	}
+}
+
+// There are may be many enum in a const block. The value can be an iota
+// expression, and the spec without type and value repeats the previous one
+// as golang does. The string enum members are numbered in order. It returns
+// the enums first declared in the block.
+func (p GoParser) processConst(decl *ast.GenDecl, consts *goConsts) []*Struct {
+	res := make([]*Struct, 0)
+
+	var (
+		typ    ast.Expr
+		values []ast.Expr
+	)
+	for iota, spec := range decl.Specs {
+		v, ok := spec.(*ast.ValueSpec)
 		if !ok {
 			continue
 		}
-		if p.isBasicType(typeIdent.Name) {
-			continue
+		if v.Type != nil || len(v.Values) > 0 {
+			typ, values = v.Type, v.Values
 		}
 
-		typeName := typeIdent.Name
-		st := m[typeName]
-		if st == nil {
-			st = &Struct{
-				Type: &EnumType{
-					Name: typeName,
-				},
+		for i, ident := range v.Names {
+			if i >= len(values) {
+				break
 			}
-		}
+			value := p.evalConst(values[i], int64(iota), consts)
+			if value.Kind() == constant.Unknown || ident.Name == "_" {
+				continue
+			}
+			consts.values[ident.Name] = value
 
-		if len(v.Values) == 0 {
-			continue
+			typeName := p.constType(values[i], consts)
+			if typ != nil {
+				typeIdent, ok := typ.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				typeName = typeIdent.Name
+			}
+			if typeName == "" || p.isBasicType(typeName) {
+				continue
+			}
+			consts.types[ident.Name] = typeName
+			if !ident.IsExported() {
+				continue
+			}
+
+			enumName := p.typeName(p.pkg, typeName)
+			st := consts.enums[enumName]
+			if st == nil {
+				st = &Struct{
+					Type: &EnumType{
+						Name: enumName,
+					},
+				}
+				consts.enums[enumName] = st
+				res = append(res, st)
+			}
+
+			field := ident.Name
+			if p.generated {
+				// the generated enum constant is prefixed by the type name,
+				// such as `Status_OK` of protoc-gen-go and Kitex
+				field = strings.TrimPrefix(field, typeName+"_")
+			}
+			member := &Member{
+				Field:   field,
+				Type:    st.Type,
+				Comment: p.parseComment(v.Doc, v.Comment),
+			}
+			switch value.Kind() {
+			case constant.Int:
+				index, ok := constant.Int64Val(value)
+				if !ok {
+					continue
+				}
+				member.Index = int(index)
+			case constant.String:
+				member.Index = len(st.Members)
+				member.Value = &Value{
+					Kind: ValueString,
+					Text: constant.StringVal(value),
+				}
+			default:
+				continue
+			}
+			st.Members = append(st.Members, member)
 		}
+	}
+	return res
+}
 
-		value, ok := v.Values[0].(*ast.BasicLit)
-		if !ok {
-			continue
+// constType gets the type name of the untyped constant spec from its value,
+// such as `B = A + 1` has the type of A, it returns empty if the value is
+// untyped
+func (p GoParser) constType(expr ast.Expr, consts *goConsts) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return consts.types[e.Name]
+	case *ast.ParenExpr:
+		return p.constType(e.X, consts)
+	case *ast.UnaryExpr:
+		return p.constType(e.X, consts)
+	case *ast.CallExpr:
+		if fun, ok := e.Fun.(*ast.Ident); ok && len(e.Args) == 1 {
+			return fun.Name
 		}
-		valueInt, err := strconv.ParseInt(value.Value, 10, 64)
-		if err != nil {
-			continue
+	case *ast.BinaryExpr:
+		if t := p.constType(e.X, consts); t != "" || e.Op == token.SHL || e.Op == token.SHR {
+			return t
 		}
-
-		st.Members = append(st.Members, &Member{
-			Field:   name,
-			Type:    st.Type,
-			Index:   int(valueInt),
-			Comment: p.parseComment(v.Doc, v.Comment),
-		})
-
-		m[typeName] = st
+		return p.constType(e.Y, consts)
 	}
+	return ""
+}
 
-	res := make([]*Struct, 0, len(m))
-	for _, st := range m {
-		res = append(res, st)
+// evalConst evaluates the constant expression, it returns an unknown value
+// if the expression can not be evaluated
+func (p GoParser) evalConst(expr ast.Expr, iota int64, consts *goConsts) constant.Value {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return constant.MakeFromLiteral(e.Value, e.Kind, 0)
+	case *ast.Ident:
+		if e.Name == "iota" {
+			return constant.MakeInt64(iota)
+		}
+		if v, ok := consts.values[e.Name]; ok {
+			return v
+		}
+	case *ast.ParenExpr:
+		return p.evalConst(e.X, iota, consts)
+	case *ast.CallExpr:
+		// the conversion, such as `Kind(1)`
+		if len(e.Args) == 1 {
+			return p.evalConst(e.Args[0], iota, consts)
+		}
+	case *ast.UnaryExpr:
+		x := p.evalConst(e.X, iota, consts)
+		if x.Kind() == constant.Int && (e.Op == token.ADD || e.Op == token.SUB || e.Op == token.XOR) {
+			return constant.UnaryOp(e.Op, x, 0)
+		}
+	case *ast.BinaryExpr:
+		x := p.evalConst(e.X, iota, consts)
+		y := p.evalConst(e.Y, iota, consts)
+		switch {
+		case x.Kind() == constant.String && y.Kind() == constant.String:
+			if e.Op == token.ADD {
+				return constant.BinaryOp(x, e.Op, y)
+			}
+		case x.Kind() == constant.Int && y.Kind() == constant.Int:
+			switch e.Op {
+			case token.SHL, token.SHR:
+				if s, ok := constant.Uint64Val(y); ok {
+					return constant.Shift(x, e.Op, uint(s))
+				}
+			case token.QUO:
+				if constant.Sign(y) != 0 {
+					return constant.BinaryOp(x, token.QUO_ASSIGN, y)
+				}
+			case token.REM:
+				if constant.Sign(y) != 0 {
+					return constant.BinaryOp(x, e.Op, y)
+				}
+			case token.ADD, token.SUB, token.MUL, token.AND, token.OR, token.XOR, token.AND_NOT:
+				return constant.BinaryOp(x, e.Op, y)
+			}
+		}
 	}
-	return res
+	return constant.MakeUnknown()
 }
 
 func (p GoParser) parseComment(doc *ast.CommentGroup, comment *ast.CommentGroup) Comment {
@@ -241,8 +888,35 @@ func (p GoParser) isBasicType(str string) bool {
 func (p GoParser) type2Type(t ast.Expr) Type {
 	switch t := t.(type) {
 	case *ast.Ident:
-		return p.nameType(t.Name)
+		if param, ok := p.params[t.Name]; ok {
+			return param
+		}
+		return p.namedType(t.Name, p.pkg)
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		if name, _, args, ok := p.genericStruct(t); ok {
+			return p.instantiate(name, args)
+		}
+		// the generic type declared outside of the parsed packages is
+		// referenced by the name of the instantiation
+		x, args, ok := p.typeArgs(t)
+		if !ok {
+			return nil
+		}
+		generic, ok := p.type2Type(x).(*StructLikeType)
+		if !ok {
+			return nil
+		}
+		name := generic.Name
+		for _, arg := range args {
+			name += goTypeArgName(arg)
+		}
+		return &StructLikeType{
+			Name: name,
+		}
 	case *ast.ArrayType:
+		if elt, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && (elt.Name == StrByte || elt.Name == StrUint8) {
+			return BinaryVal
+		}
 		return &ArrayType{
 			ChildType: p.type2Type(t.Elt),
 		}
@@ -253,7 +927,16 @@ func (p GoParser) type2Type(t ast.Expr) Type {
 		}
 	case *ast.StarExpr:
 		return p.type2Type(t.X)
+	case *ast.InterfaceType:
+		return AnyVal
 	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok && p.imports[x.Name] != nil {
+			// the type is declared in the parsed packages
+			return p.namedType(t.Sel.Name, p.imports[x.Name])
+		}
+		if m, ok := p.selectorMapping(t); ok {
+			return m.Type
+		}
 		selector := p.type2Type(t.X)
 		sub := p.type2Type(t.Sel)
 		return &StructLikeType{
@@ -263,6 +946,124 @@ func (p GoParser) type2Type(t ast.Expr) Type {
 	return nil
 }
 
+// namedType gets the type of the name declared in the package pkg by the
+// type information, the named type which is not a struct or an enum is
+// resolved to its underlying type, or kept as a typedef if [Context.Typedef]
+// is set
+func (p GoParser) namedType(name string, pkg *types.Package) Type {
+	if pkg != nil {
+		if tn, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok {
+			if t := p.goType2Type(tn.Type()); t != nil {
+				return t
+			}
+		}
+	}
+	return p.nameType(name)
+}
+
+// goType2Type converts the checked type, it returns nil if the type is
+// invalid, such as the type from a package which is not parsed
+func (p GoParser) goType2Type(t types.Type) Type {
+	switch t := t.(type) {
+	case *types.Alias:
+		rhs := types.Unalias(t)
+		if _, ok := rhs.(*types.Named); ok || !p.ctx.Typedef {
+			return p.goType2Type(rhs)
+		}
+		return p.typedefType(p.typeName(t.Obj().Pkg(), t.Obj().Name()), rhs)
+	case *types.Named:
+		name := p.typeName(t.Obj().Pkg(), t.Obj().Name())
+		if t.TypeArgs().Len() > 0 {
+			args := make([]Type, 0, t.TypeArgs().Len())
+			for arg := range t.TypeArgs().Types() {
+				a := p.goType2Type(arg)
+				if a == nil {
+					return nil
+				}
+				args = append(args, a)
+			}
+			return p.instantiate(name, args)
+		}
+		switch u := t.Underlying().(type) {
+		case *types.Struct:
+			return &StructLikeType{
+				Name: name,
+			}
+		case *types.Basic:
+			if p.isGoEnum(t, u) {
+				return &EnumType{
+					Name: name,
+				}
+			}
+		}
+		if p.ctx.Typedef {
+			return p.typedefType(name, t.Underlying())
+		}
+		return p.goType2Type(t.Underlying())
+	case *types.Basic:
+		if t.Kind() == types.Invalid {
+			return nil
+		}
+		return p.nameType(t.Name())
+	case *types.Pointer:
+		return p.goType2Type(t.Elem())
+	case *types.Slice:
+		if elem, ok := t.Elem().(*types.Basic); ok && elem.Kind() == types.Byte {
+			return BinaryVal
+		}
+		if child := p.goType2Type(t.Elem()); child != nil {
+			return &ArrayType{
+				ChildType: child,
+			}
+		}
+	case *types.Array:
+		if child := p.goType2Type(t.Elem()); child != nil {
+			return &ArrayType{
+				ChildType: child,
+			}
+		}
+	case *types.Map:
+		key := p.goType2Type(t.Key())
+		value := p.goType2Type(t.Elem())
+		if key != nil && value != nil {
+			return &MapType{
+				Key:   key,
+				Value: value,
+			}
+		}
+	case *types.Interface:
+		return AnyVal
+	}
+	return nil
+}
+
+func (p GoParser) typedefType(name string, underlying types.Type) Type {
+	t := p.goType2Type(underlying)
+	if t == nil {
+		return nil
+	}
+	return &TypedefType{
+		Name: name,
+		Type: t,
+	}
+}
+
+// isGoEnum reports whether the named integer or string type has exported
+// constants, which are the members of the enum
+func (p GoParser) isGoEnum(named *types.Named, underlying *types.Basic) bool {
+	if underlying.Info()&(types.IsInteger|types.IsString) == 0 || named.Obj().Pkg() == nil {
+		return false
+	}
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && c.Exported() && types.Identical(c.Type(), named) {
+			return true
+		}
+	}
+	return false
+}
+
 func (p GoParser) nameType(name string) Type {
 	switch name {
 	case StrInt:
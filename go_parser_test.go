@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -176,6 +178,7 @@ type SampleMessage struct {
 							Type:     StringVal,
 							Index:    3,
 							Optional: true,
+							GoTag:    []string{`json:"hello_world"`},
 						},
 						{
 							Field: "mm",
@@ -268,6 +271,825 @@ type SampleMessage struct {
 				},
 			},
 		},
+		{
+			name: "grouped type, multi-name and embedded field",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type (
+	// Base is base
+	Base struct {
+		ID int64
+	}
+
+	User struct {
+		Base
+		X, Y int32
+		Ext` + " `json:\"ext\"`" + `
+	}
+)
+
+type Ext struct {
+	A string
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Base",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type:  Int64Val,
+							Index: 1,
+						},
+					},
+					Comment: Comment{
+						BeginningComments: []string{"// Base is base"},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type:  Int64Val,
+							Index: 1,
+						},
+						{
+							Field: "x",
+							Type:  Int32Val,
+							Index: 2,
+						},
+						{
+							Field: "y",
+							Type:  Int32Val,
+							Index: 3,
+						},
+						{
+							Field: "ext",
+							Type: &StructLikeType{
+								Name: "Ext",
+							},
+							Index: 4,
+							GoTag: []string{`json:"ext"`},
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Ext",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "a",
+							Type:  StringVal,
+							Index: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "embedded field reference",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{
+					Embed: EmbedReference,
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type (
+	// Base is base
+	Base struct {
+		ID int64
+	}
+
+	User struct {
+		Base
+		X, Y int32
+		Ext` + " `json:\"ext\"`" + `
+	}
+)
+
+type Ext struct {
+	A string
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Base",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type:  Int64Val,
+							Index: 1,
+						},
+					},
+					Comment: Comment{
+						BeginningComments: []string{"// Base is base"},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "base",
+							Type: &StructLikeType{
+								Name: "Base",
+							},
+							Index: 1,
+						},
+						{
+							Field: "x",
+							Type:  Int32Val,
+							Index: 2,
+						},
+						{
+							Field: "y",
+							Type:  Int32Val,
+							Index: 3,
+						},
+						{
+							Field: "ext",
+							Type: &StructLikeType{
+								Name: "Ext",
+							},
+							Index: 4,
+							GoTag: []string{`json:"ext"`},
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Ext",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "a",
+							Type:  StringVal,
+							Index: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "iota and string enum",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Kind int
+
+const (
+	_ Kind = iota
+	KindA
+	kindB
+	KindC = KindA + 10
+)
+
+type Color string
+
+const (
+	Red  Color = "red"
+	Blue Color = "blue"
+)
+
+type Paint struct {
+	C Color
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &EnumType{
+						Name: "Kind",
+					},
+					Members: []*Member{
+						{
+							Field: "KindA",
+							Type: &EnumType{
+								Name: "Kind",
+							},
+							Index: 1,
+						},
+						{
+							Field: "KindC",
+							Type: &EnumType{
+								Name: "Kind",
+							},
+							Index: 11,
+						},
+					},
+				},
+				{
+					Type: &EnumType{
+						Name: "Color",
+					},
+					Members: []*Member{
+						{
+							Field: "Red",
+							Type: &EnumType{
+								Name: "Color",
+							},
+							Index: 0,
+							Value: &Value{
+								Kind: ValueString,
+								Text: "red",
+							},
+						},
+						{
+							Field: "Blue",
+							Type: &EnumType{
+								Name: "Color",
+							},
+							Index: 1,
+							Value: &Value{
+								Kind: ValueString,
+								Text: "blue",
+							},
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Paint",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "c",
+							Type: &EnumType{
+								Name: "Color",
+							},
+							Index: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "named and alias types",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type UserID int64
+type Tags []string
+type Meta = map[string]string
+type Owner = User
+
+type User struct {
+	ID    UserID
+	Tags  Tags
+	Meta  Meta
+	Owner *Owner
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type:  Int64Val,
+							Index: 1,
+						},
+						{
+							Field: "tags",
+							Type: &ArrayType{
+								ChildType: StringVal,
+							},
+							Index: 2,
+						},
+						{
+							Field: "meta",
+							Type: &MapType{
+								Key:   StringVal,
+								Value: StringVal,
+							},
+							Index: 3,
+						},
+						{
+							Field: "owner",
+							Type: &StructLikeType{
+								Name: "User",
+							},
+							Index: 4,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "std types",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Order struct {
+	ID        uuid.UUID
+	CreatedAt *time.Time
+	Note      sql.NullString
+	Extra     json.RawMessage
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Order",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type:  StringVal,
+							Index: 1,
+						},
+						{
+							Field:    "created_at",
+							Type:     TimestampVal,
+							Index:    2,
+							Optional: true,
+						},
+						{
+							Field:    "note",
+							Type:     StringVal,
+							Index:    3,
+							Optional: true,
+						},
+						{
+							Field: "extra",
+							Type:  AnyVal,
+							Index: 4,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "custom type mapping",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{
+					GoTypeMappings: map[string]GoTypeMapping{
+						"example.com/money.Amount": {Type: Int64Val},
+					},
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+import money "example.com/money"
+
+type Order struct {
+	Amount money.Amount
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Order",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "amount",
+							Type:  Int64Val,
+							Index: 1,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "generics",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Page[T any] struct {
+	Items []T
+	Next  *Page[T]
+}
+
+type Base[T any] struct {
+	ID T
+}
+
+type User struct {
+	Base[int64]
+	Users Page[User]
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type:  Int64Val,
+							Index: 1,
+						},
+						{
+							Field: "users",
+							Type: &StructLikeType{
+								Name: "PageUser",
+							},
+							Index: 2,
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "PageUser",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "items",
+							Type: &ArrayType{
+								ChildType: &StructLikeType{
+									Name: "User",
+								},
+							},
+							Index: 1,
+						},
+						{
+							Field: "next",
+							Type: &StructLikeType{
+								Name: "PageUser",
+							},
+							Index: 2,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "struct tags",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type User struct {
+	Name   string` + " `db:\"name\" json:\"user_name,omitempty\"`" + `
+	Secret string` + " `json:\"-\"`" + `
+	ID     int64` + " `protobuf:\"varint,1,opt,name=uid,proto3\" thrift:\"uid,1,optional\"`" + `
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field:    "user_name",
+							Type:     StringVal,
+							Index:    2,
+							Optional: true,
+							GoTag:    []string{`db:"name"`, `json:"user_name,omitempty"`},
+						},
+						{
+							Field:    "id",
+							Type:     Int64Val,
+							Index:    1,
+							Optional: false,
+							GoTag:    []string{`protobuf:"varint,1,opt,name=uid,proto3"`, `thrift:"uid,1,optional"`},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "struct tags with protobuf key",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{
+					TagKey: "protobuf",
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type User struct {
+	Name   string` + " `db:\"name\" json:\"user_name,omitempty\"`" + `
+	Secret string` + " `json:\"-\"`" + `
+	ID     int64` + " `protobuf:\"varint,1,opt,name=uid,proto3\" thrift:\"uid,1,optional\"`" + `
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field:    "name",
+							Type:     StringVal,
+							Index:    2,
+							Optional: false,
+							GoTag:    []string{`db:"name"`, `json:"user_name,omitempty"`},
+						},
+						{
+							Field:    "secret",
+							Type:     StringVal,
+							Index:    3,
+							Optional: false,
+							GoTag:    []string{`json:"-"`},
+						},
+						{
+							Field:    "uid",
+							Type:     Int64Val,
+							Index:    1,
+							Optional: false,
+							GoTag:    []string{`protobuf:"varint,1,opt,name=uid,proto3"`, `thrift:"uid,1,optional"`},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "struct tags with thrift key",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{
+					TagKey: "thrift",
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type User struct {
+	Name   string` + " `db:\"name\" json:\"user_name,omitempty\"`" + `
+	Secret string` + " `json:\"-\"`" + `
+	ID     int64` + " `protobuf:\"varint,1,opt,name=uid,proto3\" thrift:\"uid,1,optional\"`" + `
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field:    "name",
+							Type:     StringVal,
+							Index:    2,
+							Optional: false,
+							GoTag:    []string{`db:"name"`, `json:"user_name,omitempty"`},
+						},
+						{
+							Field:    "secret",
+							Type:     StringVal,
+							Index:    3,
+							Optional: false,
+							GoTag:    []string{`json:"-"`},
+						},
+						{
+							Field:    "uid",
+							Type:     Int64Val,
+							Index:    1,
+							Optional: true,
+							GoTag:    []string{`protobuf:"varint,1,opt,name=uid,proto3"`, `thrift:"uid,1,optional"`},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "field numbers from tags",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Order struct {
+	ID    int64` + " `protobuf:\"varint,2,opt,name=id,proto3\" json:\"id,omitempty\"`" + `
+	Name  string` + " `thrift:\"name,5\" json:\"name\"`" + `
+	Note  string` + " `st2:\"index=9\" protobuf:\"bytes,3,opt,name=note\"`" + `
+	Extra string
+}
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Order",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field:    "id",
+							Type:     Int64Val,
+							Index:    2,
+							Optional: true,
+							GoTag:    []string{`protobuf:"varint,2,opt,name=id,proto3"`, `json:"id,omitempty"`},
+						},
+						{
+							Field:    "name",
+							Type:     StringVal,
+							Index:    5,
+							Optional: false,
+							GoTag:    []string{`thrift:"name,5"`, `json:"name"`},
+						},
+						{
+							Field:    "note",
+							Type:     StringVal,
+							Index:    9,
+							Optional: false,
+							GoTag:    []string{`st2:"index=9"`, `protobuf:"bytes,3,opt,name=note"`},
+						},
+						{
+							Field:    "extra",
+							Type:     StringVal,
+							Index:    1,
+							Optional: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate field numbers from tags",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Order struct {
+	ID   int64` + " `protobuf:\"varint,1,opt,name=id,proto3\"`" + `
+	Name string` + " `thrift:\"name,1\"`" + `
+}
+`)),
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "generated enum value maps",
+			init: func(t *testing.T) GoParser {
+				return *NewGoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package main
+
+type Outer_Mode int32
+
+const (
+	Outer_A Outer_Mode = 0
+	Outer_B Outer_Mode = 1
+	Outer_C Outer_Mode = 1
+)
+
+var (
+	Outer_Mode_name = map[int32]string{
+		0: "A",
+		1: "B",
+	}
+	Outer_Mode_value = map[string]int32{
+		"A": 0,
+		"B": 1,
+		"C": 1,
+		"D": 2,
+	}
+)
+`)),
+				}
+			},
+			wantErr: false,
+			want1: []*Struct{
+				{
+					Type: &EnumType{
+						Name: "Outer_Mode",
+					},
+					Members: []*Member{
+						{
+							Field: "A",
+							Type:  &EnumType{Name: "Outer_Mode"},
+							Index: 0,
+						},
+						{
+							Field: "B",
+							Type:  &EnumType{Name: "Outer_Mode"},
+							Index: 1,
+						},
+						{
+							Field: "C",
+							Type:  &EnumType{Name: "Outer_Mode"},
+							Index: 1,
+						},
+						{
+							Field: "D",
+							Type:  &EnumType{Name: "Outer_Mode"},
+							Index: 2,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -297,3 +1119,311 @@ type SampleMessage struct {
 		})
 	}
 }
+
+func TestGoParser_ParsePackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n",
+		"model/user.go": `package model
+
+import "example.com/app/model/common"
+
+type User struct {
+	common.Base
+	Status common.Status
+	Addr   *Address
+}
+`,
+		"model/address.go": `package model
+
+type Address struct {
+	City string
+}
+`,
+		"model/ignored.go": `//go:build ignore
+
+package model
+
+type Ignored struct{}
+`,
+		"model/user_test.go": `package model
+
+type InTest struct{}
+`,
+		"model/testdata/data.go": `package data
+
+type InTestdata struct{}
+`,
+		"model/common/common.go": `package common
+
+type Status int
+
+const (
+	StatusOK Status = iota
+)
+
+type Base struct {
+	ID int64
+}
+`,
+		"dup/a/item.go": `package a
+
+type Kind int
+
+const (
+	KindA Kind = iota
+)
+
+type Item struct {
+	ID   int64
+	Kind Kind
+}
+`,
+		"dup/b/item.go": `package b
+
+import "example.com/app/dup/a"
+
+type Kind int
+
+const (
+	KindB Kind = iota
+)
+
+type Item struct {
+	Name   string
+	Kind   Kind
+	Origin *a.Item
+}
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want1   *File
+		wantErr bool
+	}{
+		{
+			name:    "recursive",
+			pattern: filepath.Join(dir, "model") + "/...",
+			want1: &File{
+				Package: "model",
+				Structs: []*Struct{
+					{
+						Type: &StructLikeType{
+							Name:   "Address",
+							Source: SLSStruct,
+						},
+						Members: []*Member{
+							{
+								Field: "city",
+								Type:  StringVal,
+								Index: 1,
+							},
+						},
+					},
+					{
+						Type: &StructLikeType{
+							Name:   "User",
+							Source: SLSStruct,
+						},
+						Members: []*Member{
+							{
+								Field: "id",
+								Type:  Int64Val,
+								Index: 1,
+							},
+							{
+								Field: "status",
+								Type: &EnumType{
+									Name: "Status",
+								},
+								Index: 2,
+							},
+							{
+								Field: "addr",
+								Type: &StructLikeType{
+									Name: "Address",
+								},
+								Index: 3,
+							},
+						},
+					},
+					{
+						Type: &EnumType{
+							Name: "Status",
+						},
+						Members: []*Member{
+							{
+								Field: "StatusOK",
+								Type: &EnumType{
+									Name: "Status",
+								},
+								Index: 0,
+							},
+						},
+					},
+					{
+						Type: &StructLikeType{
+							Name:   "Base",
+							Source: SLSStruct,
+						},
+						Members: []*Member{
+							{
+								Field: "id",
+								Type:  Int64Val,
+								Index: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "single package",
+			pattern: filepath.Join(dir, "model", "common"),
+			want1: &File{
+				Package: "common",
+				Structs: []*Struct{
+					{
+						Type: &EnumType{
+							Name: "Status",
+						},
+						Members: []*Member{
+							{
+								Field: "StatusOK",
+								Type: &EnumType{
+									Name: "Status",
+								},
+								Index: 0,
+							},
+						},
+					},
+					{
+						Type: &StructLikeType{
+							Name:   "Base",
+							Source: SLSStruct,
+						},
+						Members: []*Member{
+							{
+								Field: "id",
+								Type:  Int64Val,
+								Index: 1,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "no go files",
+			pattern: dir,
+			wantErr: true,
+		},
+		{
+			name:    "same type name in packages",
+			pattern: filepath.Join(dir, "dup") + "/...",
+			want1: &File{
+				Package: "a",
+				Structs: []*Struct{
+					{
+						Type: &EnumType{
+							Name: "aKind",
+						},
+						Members: []*Member{
+							{
+								Field: "KindA",
+								Type: &EnumType{
+									Name: "aKind",
+								},
+								Index: 0,
+							},
+						},
+					},
+					{
+						Type: &StructLikeType{
+							Name:   "aItem",
+							Source: SLSStruct,
+						},
+						Members: []*Member{
+							{
+								Field: "id",
+								Type:  Int64Val,
+								Index: 1,
+							},
+							{
+								Field: "kind",
+								Type: &EnumType{
+									Name: "aKind",
+								},
+								Index: 2,
+							},
+						},
+					},
+					{
+						Type: &EnumType{
+							Name: "bKind",
+						},
+						Members: []*Member{
+							{
+								Field: "KindB",
+								Type: &EnumType{
+									Name: "bKind",
+								},
+								Index: 0,
+							},
+						},
+					},
+					{
+						Type: &StructLikeType{
+							Name:   "bItem",
+							Source: SLSStruct,
+						},
+						Members: []*Member{
+							{
+								Field: "name",
+								Type:  StringVal,
+								Index: 1,
+							},
+							{
+								Field: "kind",
+								Type: &EnumType{
+									Name: "bKind",
+								},
+								Index: 2,
+							},
+							{
+								Field: "origin",
+								Type: &StructLikeType{
+									Name: "aItem",
+								},
+								Index: 3,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got1, err := NewGoParser(Context{}).ParsePackage(tt.pattern)
+
+			if !reflect.DeepEqual(got1, tt.want1) {
+				got1json, _ := json.MarshalIndent(got1, "", "  ")
+				want1json, _ := json.MarshalIndent(tt.want1, "", "  ")
+				t.Errorf("GoParser.ParsePackage got1 = %v, want1: %v", string(got1json), string(want1json))
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GoParser.ParsePackage error = %v, wantErr: %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package st2
+
+import (
+	"fmt"
+	"go/ast"
+	"strconv"
+	"strings"
+)
+
+// goTag is a key value pair of the golang struct tag
+type goTag struct {
+	Key   string
+	Value string
+}
+
+// String get the tag pair as it is in the struct tag, such as `json:"a"`
+func (t goTag) String() string {
+	return fmt.Sprintf("%s:%s", t.Key, strconv.Quote(t.Value))
+}
+
+// goTags is the key value pairs of the golang struct tag in order
+type goTags []goTag
+
+// parseGoTag parses the golang struct tag literal with the conventional
+// format as [reflect.StructTag] does, the malformed part is dropped
+func parseGoTag(lit *ast.BasicLit) goTags {
+	if lit == nil {
+		return nil
+	}
+	tag, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return nil
+	}
+
+	var tags goTags
+	for tag != "" {
+		// skip leading space
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		// scan to colon, a space, a quote or a control character is a
+		// syntax error
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		// scan quoted string to find value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		quoted := tag[:i+1]
+		tag = tag[i+1:]
+
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			break
+		}
+		tags = append(tags, goTag{Key: key, Value: value})
+	}
+	return tags
+}
+
+// Lookup get the value of the key
+func (tags goTags) Lookup(key string) (string, bool) {
+	for _, tag := range tags {
+		if tag.Key == key {
+			return tag.Value, true
+		}
+	}
+	return "", false
+}
+
+// Strings get the tag pairs as they are in the struct tag
+func (tags goTags) Strings() []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	res := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		res = append(res, tag.String())
+	}
+	return res
+}
+
+// goTagField is the field information from the authoritative tag key
+type goTagField struct {
+	// Name is the field name, it is empty if the tag does not name the field
+	Name string
+	// Optional reports whether the field is omitted if it is empty, such as
+	// `json:",omitempty"` and `thrift:"a,1,optional"`
+	Optional bool
+	// Ignored reports whether the field is ignored, such as `json:"-"`
+	Ignored bool
+}
+
+// tagKey gets the authoritative tag key of [Context.TagKey]
+func (p GoParser) tagKey() string {
+	if p.ctx.TagKey == "" {
+		return TagKeyDefault
+	}
+	return p.ctx.TagKey
+}
+
+// tagField gets the field information from the value of the authoritative
+// tag key of [Context.TagKey], the protobuf tag names the field by the
+// `name=` option, such as `protobuf:"varint,1,opt,name=id,proto3"`. The
+// field of the generated code without the authoritative tag key is named by
+// its protobuf or thrift tag, such as the oneof wrapper of protoc-gen-go.
+func (p GoParser) tagField(tags goTags) goTagField {
+	key := p.tagKey()
+	value, ok := tags.Lookup(key)
+	if !ok && p.generated {
+		for _, key = range []string{TagKeyProtobuf, TagKeyThrift} {
+			if value, ok = tags.Lookup(key); ok {
+				break
+			}
+		}
+	}
+	if !ok {
+		return goTagField{}
+	}
+	if value == "-" {
+		return goTagField{Ignored: true}
+	}
+
+	items := strings.Split(value, ",")
+	field := goTagField{}
+	if key != TagKeyProtobuf {
+		field.Name = items[0]
+		items = items[1:]
+	}
+	for _, item := range items {
+		switch {
+		case key == TagKeyProtobuf && strings.HasPrefix(item, "name="):
+			field.Name = strings.TrimPrefix(item, "name=")
+		case item == "omitempty" || item == "omitzero" || item == "optional":
+			field.Optional = true
+		}
+	}
+	return field
+}
+
+// optional gets the optional-ness from the protobuf or thrift tag, the tag
+// of the key is preferred if both exist. The proto3 optional field has the
+// `oneof` option, and the proto2 one has the `opt` label, such as
+// `protobuf:"bytes,2,opt,name=x,proto3,oneof"`. It returns false if none of
+// them exists.
+func (tags goTags) optional(key string) (bool, bool) {
+	keys := []string{TagKeyProtobuf, TagKeyThrift}
+	if key == TagKeyThrift {
+		keys = []string{TagKeyThrift, TagKeyProtobuf}
+	}
+	for _, key := range keys {
+		value, ok := tags.Lookup(key)
+		if !ok {
+			continue
+		}
+		items := strings.Split(value, ",")
+		if key == TagKeyThrift {
+			return len(items) > 2 && items[2] == "optional", true
+		}
+		proto3, oneof := false, false
+		for _, item := range items[min(len(items), 3):] {
+			proto3 = proto3 || item == "proto3"
+			oneof = oneof || item == "oneof"
+		}
+		return len(items) > 2 && items[2] == "opt" && (!proto3 || oneof), true
+	}
+	return false, false
+}
+
+// index gets the field number from the tags, the explicit `st2:"index=5"`
+// takes precedence over the number of the tags generated by protoc-gen-go
+// and Kitex, such as `protobuf:"varint,3,opt,name=x"` and `thrift:"x,3"`.
+// It returns false if none of them numbers the field.
+func (tags goTags) index() (int, bool) {
+	if value, ok := tags.Lookup(TagKeySt2); ok {
+		for _, item := range strings.Split(value, ",") {
+			if !strings.HasPrefix(item, "index=") {
+				continue
+			}
+			if index, ok := positiveInt(strings.TrimPrefix(item, "index=")); ok {
+				return index, true
+			}
+		}
+	}
+	for _, key := range []string{TagKeyProtobuf, TagKeyThrift} {
+		value, ok := tags.Lookup(key)
+		if !ok {
+			continue
+		}
+		// the number is the second item of both tags
+		items := strings.Split(value, ",")
+		if len(items) < 2 {
+			continue
+		}
+		if index, ok := positiveInt(items[1]); ok {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+func positiveInt(s string) (int, bool) {
+	i, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || i <= 0 {
+		return 0, false
+	}
+	return i, true
+}
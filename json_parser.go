@@ -1,6 +1,8 @@
 package st2
 
 import (
+	"bytes"
+
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -14,6 +16,21 @@ func (j JsonUnmarshalTagFormat) Unmarshal(data []byte, v any) error {
 	return jsonapi.Unmarshal(data, v)
 }
 
+// UnmarshalSamples unmarshal a stream of json values, such as ndjson
+func (j JsonUnmarshalTagFormat) UnmarshalSamples(data []byte) ([]any, error) {
+	samples := make([]any, 0)
+	decoder := jsonapi.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var v any
+		err := decoder.Decode(&v)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, v)
+	}
+	return samples, nil
+}
+
 func (j JsonUnmarshalTagFormat) TagFormat() string {
 	return `json:"%s,omitempty"`
 }
@@ -659,6 +659,7 @@ func TestJsonParser_Parse(t *testing.T) {
 							},
 							Index: 1,
 							GoTag: []string{`json:"e是e,omitempty"`},
+							Name:  "e是e",
 						},
 						{
 							Field: "ggg",
@@ -671,6 +672,7 @@ func TestJsonParser_Parse(t *testing.T) {
 							},
 							Index: 2,
 							GoTag: []string{`json:"g gg,omitempty"`},
+							Name:  "g gg",
 						},
 						{
 							Field: "A",
@@ -679,6 +681,7 @@ func TestJsonParser_Parse(t *testing.T) {
 							},
 							Index: 3,
 							GoTag: []string{`json:"你好,omitempty"`},
+							Name:  "你好",
 						},
 					},
 				},
@@ -686,6 +689,204 @@ func TestJsonParser_Parse(t *testing.T) {
 			wantErr:    false,
 			inspectErr: func(err error, t *testing.T) {},
 		},
+		{
+			name: "merge ndjson samples",
+			init: func(t *testing.T) *StructuredParser {
+				return NewJsonParser(Context{
+					Merge: true,
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`{"a": 1, "l": [{"p": 1}, {"q": "x"}]}
+{"a": 2.5, "b": true, "l": []}
+`)),
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "L",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field:    "p",
+							Type:     Int64Val,
+							Index:    1,
+							Optional: true,
+							GoTag:    []string{`json:"p,omitempty"`},
+						},
+						{
+							Field:    "q",
+							Type:     StringVal,
+							Index:    2,
+							Optional: true,
+							GoTag:    []string{`json:"q,omitempty"`},
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Root",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "a",
+							Type:  Float64Val,
+							Index: 1,
+							GoTag: []string{`json:"a,omitempty"`},
+						},
+						{
+							Field:    "b",
+							Type:     BoolVal,
+							Index:    2,
+							Optional: true,
+							GoTag:    []string{`json:"b,omitempty"`},
+						},
+						{
+							Field: "l",
+							Type: &ArrayType{
+								ChildType: &StructLikeType{
+									Name: "L",
+								},
+							},
+							Index: 3,
+							GoTag: []string{`json:"l,omitempty"`},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "merge array samples",
+			init: func(t *testing.T) *StructuredParser {
+				return NewJsonParser(Context{
+					Merge: true,
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`[{"a": 1}, {"a": "x", "b": 1}]`)),
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Root",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "a",
+							Type:  AnyVal,
+							Index: 1,
+							GoTag: []string{`json:"a,omitempty"`},
+						},
+						{
+							Field:    "b",
+							Type:     Int64Val,
+							Index:    2,
+							Optional: true,
+							GoTag:    []string{`json:"b,omitempty"`},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "merge illegal json",
+			init: func(t *testing.T) *StructuredParser {
+				return NewJsonParser(Context{
+					Merge: true,
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`{"a": 1} a`)),
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "merge null samples",
+			init: func(t *testing.T) *StructuredParser {
+				return NewJsonParser(Context{
+					Merge: true,
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`[{"a": null, "b": null, "c": [null, 1]}, {"a": 1, "b": null, "c": null}]`)),
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Root",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field:    "a",
+							Type:     Int64Val,
+							Index:    1,
+							Optional: true,
+							GoTag:    []string{`json:"a,omitempty"`},
+						},
+						{
+							Field: "b",
+							Type:  AnyVal,
+							Index: 2,
+							GoTag: []string{`json:"b,omitempty"`},
+						},
+						{
+							Field: "c",
+							Type: &ArrayType{
+								ChildType: Int64Val,
+							},
+							Index:    3,
+							Optional: true,
+							GoTag:    []string{`json:"c,omitempty"`},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "array with null element",
+			init: func(t *testing.T) *StructuredParser {
+				return NewJsonParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`{"a": [null, "x"]}`)),
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Root",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "a",
+							Type: &ArrayType{
+								ChildType: StringVal,
+							},
+							Index: 1,
+							GoTag: []string{`json:"a,omitempty"`},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,515 @@
+package st2
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JsonSchemaParser is a Parser to parse json schema source
+type JsonSchemaParser struct {
+	ctx Context
+
+	defs    *jsonObject
+	refMap  map[string]Type
+	nameMap map[string]bool
+	structs []*Struct
+}
+
+// NewJsonSchemaParser create [JsonSchemaParser]
+func NewJsonSchemaParser(ctx Context) *JsonSchemaParser {
+	return &JsonSchemaParser{
+		ctx:     ctx,
+		refMap:  make(map[string]Type),
+		nameMap: make(map[string]bool),
+	}
+}
+
+// Parse method parse json schema source
+func (p *JsonSchemaParser) Parse(reader io.Reader) ([]*Struct, error) {
+	structs, _, err := p.parse(reader)
+	return structs, err
+}
+
+// ParseFile method parse json schema source to [File], the root is the type
+// of the root schema
+func (p *JsonSchemaParser) ParseFile(reader io.Reader) (*File, error) {
+	structs, root, err := p.parse(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &File{
+		Root:    root,
+		Structs: structs,
+	}, nil
+}
+
+func (p *JsonSchemaParser) parse(reader io.Reader) ([]*Struct, Type, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	v, err := decodeJsonObject(decoder)
+	if err != nil {
+		return nil, nil, err
+	}
+	root, ok := v.(*jsonObject)
+	if !ok {
+		return nil, nil, errors.New("json schema must be an object")
+	}
+
+	p.defs = root.object("$defs")
+	if p.defs == nil {
+		p.defs = root.object("definitions")
+	}
+	if p.defs != nil {
+		for _, name := range p.defs.keys {
+			p.nameMap[normalizeToken(name, "A")] = true
+		}
+		for _, name := range p.defs.keys {
+			p.def2Type(name)
+		}
+	}
+
+	var rootType Type
+	if root.has("properties") || root.string("type") == "object" {
+		rootName := p.ctx.Root
+		if rootName == "" {
+			rootName = normalizeToken(root.string("title"), RootDefault)
+		}
+		rootType = p.object2Type(rootName, root)
+	} else if ref := root.string("$ref"); ref != "" {
+		rootType = p.ref2Type(ref)
+	}
+
+	structs := p.structs
+	p.defs = nil
+	p.refMap = make(map[string]Type)
+	p.nameMap = make(map[string]bool)
+	p.structs = nil
+
+	return structs, rootType, nil
+}
+
+func (p *JsonSchemaParser) genUniqName(seed string) string {
+	seed = normalizeToken(camel(seed), "A")
+
+	if !p.nameMap[seed] {
+		p.nameMap[seed] = true
+		return seed
+	}
+
+	for i := 1; i < 1000; i++ {
+		name := fmt.Sprintf("%s%02d", seed, i)
+		if !p.nameMap[name] {
+			p.nameMap[name] = true
+			return name
+		}
+	}
+
+	return p.genUniqName(seed + "a")
+}
+
+func (p *JsonSchemaParser) def2Type(name string) Type {
+	if t, ok := p.refMap[name]; ok {
+		return t
+	}
+
+	schema := p.defs.object(name)
+	if schema == nil {
+		return nil
+	}
+
+	structName := normalizeToken(name, "A")
+	// register the def before resolving it, the def may reference itself
+	// through the members, the items or the variants. The def which is not
+	// a struct is any in the cycle.
+	p.refMap[name] = AnyVal
+	var t Type
+	variants, _ := notNullVariants(schemaVariants(schema))
+	switch {
+	case schema.has("enum"):
+		t = p.enum2Type(structName, schema)
+	case schema.has("properties") || schema.string("type") == "object" && !schema.has("additionalProperties"):
+		p.refMap[name] = &StructLikeType{
+			Name: structName,
+		}
+		t = p.object2Type(structName, schema)
+	case len(variants) > 1:
+		p.refMap[name] = &StructLikeType{
+			Name: structName,
+		}
+		t = p.union2Type(structName, structName, variants)
+	default:
+		t, _ = p.schema2Type(structName, schema)
+	}
+	p.refMap[name] = t
+	return t
+}
+
+func (p *JsonSchemaParser) ref2Type(ref string) Type {
+	for _, prefix := range []string{"#/$defs/", "#/definitions/"} {
+		if strings.HasPrefix(ref, prefix) && p.defs != nil {
+			if t := p.def2Type(strings.TrimPrefix(ref, prefix)); t != nil {
+				return t
+			}
+		}
+	}
+	return AnyVal
+}
+
+// schema2Type convert a schema to [Type], the second result reports whether
+// the value is nullable
+func (p *JsonSchemaParser) schema2Type(name string, schema *jsonObject) (Type, bool) {
+	if schema == nil {
+		return AnyVal, false
+	}
+
+	if ref := schema.string("$ref"); ref != "" {
+		return p.ref2Type(ref), false
+	}
+
+	if schema.has("enum") {
+		return p.enum2Type(p.genUniqName(name), schema), false
+	}
+
+	if variants := schemaVariants(schema); len(variants) > 0 {
+		return p.variants2Type(name, variants)
+	}
+
+	nullable := false
+	typeName := schema.string("type")
+	if types, ok := schema.get("type").([]any); ok {
+		notNull := make([]string, 0, len(types))
+		for _, t := range types {
+			if t == StrNull {
+				nullable = true
+			} else if str, ok := t.(string); ok {
+				notNull = append(notNull, str)
+			}
+		}
+		if len(notNull) == 1 {
+			typeName = notNull[0]
+		}
+	}
+
+	switch typeName {
+	case StrString:
+		if schema.string("format") == "byte" || schema.string("contentEncoding") == "base64" {
+			return BinaryVal, nullable
+		}
+		if schema.string("format") == FormatDateTime {
+			return TimestampVal, nullable
+		}
+		return StringVal, nullable
+	case "integer":
+		if schema.string("format") == StrInt32 {
+			return Int32Val, nullable
+		}
+		return Int64Val, nullable
+	case StrNumber:
+		if schema.string("format") == StrFloat {
+			return Float32Val, nullable
+		}
+		return Float64Val, nullable
+	case "boolean":
+		return BoolVal, nullable
+	case "array":
+		child, _ := p.schema2Type(name, schema.object("items"))
+		if schema.bool("uniqueItems") && child.IsBasicType() {
+			return &SetType{
+				Key: child,
+			}, nullable
+		}
+		return &ArrayType{
+			ChildType: child,
+		}, nullable
+	case "object", "":
+		if schema.has("properties") {
+			return p.object2Type(p.genUniqName(name), schema), nullable
+		}
+		if typeName == "" {
+			return AnyVal, nullable
+		}
+		value := Type(AnyVal)
+		if additional := schema.object("additionalProperties"); additional != nil {
+			value, _ = p.schema2Type(name, additional)
+		}
+		return &MapType{
+			Key:   StringVal,
+			Value: value,
+		}, nullable
+	}
+	return AnyVal, nullable
+}
+
+func (p *JsonSchemaParser) object2Type(name string, schema *jsonObject) Type {
+	required := make(map[string]bool)
+	if items, ok := schema.get("required").([]any); ok {
+		for _, item := range items {
+			if str, ok := item.(string); ok {
+				required[str] = true
+			}
+		}
+	}
+
+	st := &Struct{
+		Type: &StructLikeType{
+			Name:   name,
+			Source: SLSStruct,
+		},
+		Comment: p.description2Comment(schema),
+	}
+
+	if properties := schema.object("properties"); properties != nil {
+		for i, key := range properties.keys {
+			property := properties.object(key)
+			t, nullable := p.schema2Type(key, property)
+			member := &Member{
+				Field:    normalizeToken(key, "A"),
+				Type:     t,
+				Index:    i + 1,
+				Optional: nullable || !required[key],
+				Comment:  p.description2Comment(property),
+				GoTag:    []string{fmt.Sprintf(JsonUnmarshalTagFormat{}.TagFormat(), key)},
+			}
+			if member.Field != key {
+				member.Name = key
+			}
+			st.Members = append(st.Members, member)
+		}
+	}
+
+	p.structs = append(p.structs, st)
+	return &StructLikeType{
+		Name: name,
+	}
+}
+
+func (p *JsonSchemaParser) enum2Type(name string, schema *jsonObject) Type {
+	st := &Struct{
+		Type: &EnumType{
+			Name: name,
+		},
+		Comment: p.description2Comment(schema),
+	}
+
+	values, _ := schema.get("enum").([]any)
+	for i, value := range values {
+		member := &Member{
+			Type:  st.Type,
+			Index: i,
+		}
+		switch v := value.(type) {
+		case json.Number:
+			// use the number as the enum value
+			n, err := v.Int64()
+			if err != nil {
+				continue
+			}
+			member.Field = fmt.Sprintf("%s%d", name, n)
+			member.Index = int(n)
+		case string:
+			// keep the string as the enum value
+			member.Field = normalizeToken(v, "A")
+			member.Value = &Value{
+				Kind: ValueString,
+				Text: v,
+			}
+		default:
+			continue
+		}
+		st.Members = append(st.Members, member)
+	}
+
+	p.structs = append(p.structs, st)
+	return &EnumType{
+		Name: name,
+	}
+}
+
+// variants2Type convert `oneOf` or `anyOf` to [Type], a nullable variant
+// like `[{"type": "null"}, {"type": "string"}]` is converted to the non-null
+// type, other variants are converted to an union
+func (p *JsonSchemaParser) variants2Type(name string, variants []*jsonObject) (Type, bool) {
+	notNull, nullable := notNullVariants(variants)
+	if len(notNull) == 1 {
+		t, _ := p.schema2Type(name, notNull[0])
+		return t, nullable
+	}
+	return p.union2Type(p.genUniqName(name), name, notNull), nullable
+}
+
+// union2Type convert the variants to an union named name, the seed is the
+// name of the variants which are not declared in the defs
+func (p *JsonSchemaParser) union2Type(name string, seed string, variants []*jsonObject) Type {
+	st := &Struct{
+		Type: &StructLikeType{
+			Name:   name,
+			Source: SLSUnion,
+		},
+	}
+	for i, variant := range variants {
+		t, _ := p.schema2Type(fmt.Sprintf("%s_%d", seed, i+1), variant)
+		field := fmt.Sprintf("value%d", i+1)
+		switch t := t.(type) {
+		case *StructLikeType:
+			field = snake(t.Name)
+		case *EnumType:
+			field = snake(t.Name)
+		}
+		st.Members = append(st.Members, &Member{
+			Field:    field,
+			Type:     t,
+			Index:    i + 1,
+			Optional: true,
+			Comment:  p.description2Comment(variant),
+		})
+	}
+	p.structs = append(p.structs, st)
+
+	return &StructLikeType{
+		Name: name,
+	}
+}
+
+// schemaVariants get the variants of `oneOf` or `anyOf`
+func schemaVariants(schema *jsonObject) []*jsonObject {
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if variants := schema.objects(key); len(variants) > 0 {
+			return variants
+		}
+	}
+	return nil
+}
+
+// notNullVariants drops the null variants, the second result reports
+// whether there is a null variant
+func notNullVariants(variants []*jsonObject) ([]*jsonObject, bool) {
+	nullable := false
+	notNull := make([]*jsonObject, 0, len(variants))
+	for _, variant := range variants {
+		if variant.string("type") == StrNull {
+			nullable = true
+			continue
+		}
+		notNull = append(notNull, variant)
+	}
+	return notNull, nullable
+}
+
+func (p *JsonSchemaParser) description2Comment(schema *jsonObject) Comment {
+	c := Comment{}
+	if schema == nil {
+		return c
+	}
+	description := strings.TrimSpace(schema.string("description"))
+	if description == "" {
+		return c
+	}
+	for _, line := range strings.Split(description, "\n") {
+		c.BeginningComments = append(c.BeginningComments, "// "+strings.TrimSpace(line))
+	}
+	return c
+}
+
+// jsonObject is a json object which keeps the order of the keys
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func (o *jsonObject) has(key string) bool {
+	_, ok := o.values[key]
+	return ok
+}
+
+func (o *jsonObject) get(key string) any {
+	return o.values[key]
+}
+
+func (o *jsonObject) string(key string) string {
+	str, _ := o.values[key].(string)
+	return str
+}
+
+func (o *jsonObject) bool(key string) bool {
+	b, _ := o.values[key].(bool)
+	return b
+}
+
+func (o *jsonObject) object(key string) *jsonObject {
+	obj, _ := o.values[key].(*jsonObject)
+	return obj
+}
+
+func (o *jsonObject) objects(key string) []*jsonObject {
+	items, _ := o.values[key].([]any)
+	objs := make([]*jsonObject, 0, len(items))
+	for _, item := range items {
+		if obj, ok := item.(*jsonObject); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return objs
+}
+
+// decodeJsonObject decode a json value, the object is decoded to
+// [jsonObject] to keep the order of the keys
+func decodeJsonObject(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := &jsonObject{
+			values: make(map[string]any),
+		}
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := token.(string)
+			value, err := decodeJsonObject(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if !obj.has(key) {
+				obj.keys = append(obj.keys, key)
+			}
+			obj.values[key] = value
+		}
+		_, err = decoder.Token()
+		return obj, err
+	case '[':
+		arr := make([]any, 0)
+		for decoder.More() {
+			value, err := decodeJsonObject(decoder)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err = decoder.Token()
+		return arr, err
+	}
+	return nil, fmt.Errorf("unexpected delim %s", delim)
+}
@@ -0,0 +1,335 @@
+package st2
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonSchemaParser_Parse(t *testing.T) {
+	type args struct {
+		reader io.Reader
+	}
+	tests := []struct {
+		name    string
+		init    func(t *testing.T) *JsonSchemaParser
+		inspect func(r *JsonSchemaParser, t *testing.T) //inspects receiver after test run
+
+		args func(t *testing.T) args
+
+		want1      []*Struct
+		wantErr    bool
+		inspectErr func(err error, t *testing.T) //use for more precise error evaluation after test
+	}{
+		{
+			name: "empty",
+			init: func(t *testing.T) *JsonSchemaParser {
+				return NewJsonSchemaParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(" ")),
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid json",
+			init: func(t *testing.T) *JsonSchemaParser {
+				return NewJsonSchemaParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte("{")),
+				}
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.EqualError(t, err, "unexpected end of JSON input")
+			},
+		},
+		{
+			name: "not object",
+			init: func(t *testing.T) *JsonSchemaParser {
+				return NewJsonSchemaParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte("[]")),
+				}
+			},
+			wantErr: true,
+			inspectErr: func(err error, t *testing.T) {
+				assert.EqualError(t, err, "json schema must be an object")
+			},
+		},
+		{
+			name: "succ",
+			init: func(t *testing.T) *JsonSchemaParser {
+				return NewJsonSchemaParser(Context{
+					Root: "User",
+				})
+			},
+			inspect: func(r *JsonSchemaParser, t *testing.T) {
+				assert.Empty(t, r.structs)
+				assert.Empty(t, r.nameMap)
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "description": "a user",
+  "type": "object",
+  "properties": {
+    "id": {"type": "integer", "description": "the id"},
+    "name": {"type": ["string", "null"]},
+    "role": {"$ref": "#/$defs/Role"},
+    "tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true},
+    "address": {"type": "object", "properties": {"city": {"type": "string"}}, "required": ["city"]},
+    "meta": {"type": "object", "additionalProperties": {"type": "number", "format": "float"}},
+    "contact": {"oneOf": [{"$ref": "#/$defs/Email"}, {"type": "string", "format": "byte"}]},
+    "level": {"enum": [1, 2]},
+    "parent": {"anyOf": [{"$ref": "#/$defs/UserId"}, {"type": "null"}]}
+  },
+  "required": ["id", "role", "parent"],
+  "$defs": {
+    "Role": {"enum": ["admin", "guest"], "description": "role of user"},
+    "Email": {"type": "object", "properties": {"next": {"$ref": "#/$defs/Email"}}},
+    "UserId": {"type": "integer", "format": "int32"}
+  }
+}
+`)),
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &EnumType{
+						Name: "Role",
+					},
+					Members: []*Member{
+						{
+							Field: "admin",
+							Type: &EnumType{
+								Name: "Role",
+							},
+							Index: 0,
+							Value: &Value{
+								Kind: ValueString,
+								Text: "admin",
+							},
+						},
+						{
+							Field: "guest",
+							Type: &EnumType{
+								Name: "Role",
+							},
+							Index: 1,
+							Value: &Value{
+								Kind: ValueString,
+								Text: "guest",
+							},
+						},
+					},
+					Comment: Comment{
+						BeginningComments: []string{"// role of user"},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Email",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "next",
+							Type: &StructLikeType{
+								Name: "Email",
+							},
+							Index:    1,
+							Optional: true,
+							GoTag:    []string{`json:"next,omitempty"`},
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Address",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "city",
+							Type:  StringVal,
+							Index: 1,
+							GoTag: []string{`json:"city,omitempty"`},
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Contact",
+						Source: SLSUnion,
+					},
+					Members: []*Member{
+						{
+							Field: "email",
+							Type: &StructLikeType{
+								Name: "Email",
+							},
+							Index:    1,
+							Optional: true,
+						},
+						{
+							Field:    "value2",
+							Type:     BinaryVal,
+							Index:    2,
+							Optional: true,
+						},
+					},
+				},
+				{
+					Type: &EnumType{
+						Name: "Level",
+					},
+					Members: []*Member{
+						{
+							Field: "Level1",
+							Type: &EnumType{
+								Name: "Level",
+							},
+							Index: 1,
+						},
+						{
+							Field: "Level2",
+							Type: &EnumType{
+								Name: "Level",
+							},
+							Index: 2,
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Comment: Comment{
+						BeginningComments: []string{"// a user"},
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type:  Int64Val,
+							Index: 1,
+							Comment: Comment{
+								BeginningComments: []string{"// the id"},
+							},
+							GoTag: []string{`json:"id,omitempty"`},
+						},
+						{
+							Field:    "name",
+							Type:     StringVal,
+							Index:    2,
+							Optional: true,
+							GoTag:    []string{`json:"name,omitempty"`},
+						},
+						{
+							Field: "role",
+							Type: &EnumType{
+								Name: "Role",
+							},
+							Index: 3,
+							GoTag: []string{`json:"role,omitempty"`},
+						},
+						{
+							Field: "tags",
+							Type: &SetType{
+								Key: StringVal,
+							},
+							Index:    4,
+							Optional: true,
+							GoTag:    []string{`json:"tags,omitempty"`},
+						},
+						{
+							Field: "address",
+							Type: &StructLikeType{
+								Name: "Address",
+							},
+							Index:    5,
+							Optional: true,
+							GoTag:    []string{`json:"address,omitempty"`},
+						},
+						{
+							Field: "meta",
+							Type: &MapType{
+								Key:   StringVal,
+								Value: Float32Val,
+							},
+							Index:    6,
+							Optional: true,
+							GoTag:    []string{`json:"meta,omitempty"`},
+						},
+						{
+							Field: "contact",
+							Type: &StructLikeType{
+								Name: "Contact",
+							},
+							Index:    7,
+							Optional: true,
+							GoTag:    []string{`json:"contact,omitempty"`},
+						},
+						{
+							Field: "level",
+							Type: &EnumType{
+								Name: "Level",
+							},
+							Index:    8,
+							Optional: true,
+							GoTag:    []string{`json:"level,omitempty"`},
+						},
+						{
+							Field:    "parent",
+							Type:     Int32Val,
+							Index:    9,
+							Optional: true,
+							GoTag:    []string{`json:"parent,omitempty"`},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tArgs := tt.args(t)
+
+			receiver := tt.init(t)
+			got1, err := receiver.Parse(tArgs.reader)
+
+			if tt.inspect != nil {
+				tt.inspect(receiver, t)
+			}
+
+			if !reflect.DeepEqual(got1, tt.want1) {
+				got1Json, _ := json.MarshalIndent(got1, "", "  ")
+				want1Json, _ := json.MarshalIndent(tt.want1, "", "  ")
+				t.Errorf("JsonSchemaParser.Parse got1 = %v, want1: %v", string(got1Json), string(want1Json))
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("JsonSchemaParser.Parse error = %v, wantErr: %t", err, tt.wantErr)
+			}
+
+			if tt.inspectErr != nil {
+				tt.inspectErr(err, t)
+			}
+		})
+	}
+}
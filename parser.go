@@ -8,3 +8,19 @@ type Parse interface {
 	// Parse parse source code to Struct
 	Parse(r io.Reader) ([]*Struct, error)
 }
+
+// ParseFile interface has a method `ParseFile` which parse source code to a
+// [File], the parser which supports declarations other than struct, such as
+// service, should implement it
+type ParseFile interface {
+	// ParseFile parse source code to File
+	ParseFile(r io.Reader) (*File, error)
+}
+
+// ParsePackage interface has a method `ParsePackage` which parse the source
+// packages matched by a pattern to a [File], the pattern is a directory, or
+// a directory followed by `/...` to match all the sub directories
+type ParsePackage interface {
+	// ParsePackage parse the source packages to File
+	ParsePackage(pattern string) (*File, error)
+}
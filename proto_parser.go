@@ -1,13 +1,20 @@
 package st2
 
 import (
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/yoheimuta/go-protoparser/v4"
 	"github.com/yoheimuta/go-protoparser/v4/parser"
 )
 
+// protoWellKnownDir is the directory of the well-known types
+const protoWellKnownDir = "google/protobuf/"
+
 // ProtoParser is a Parser to parse protobuf source
 type ProtoParser struct {
 	ctx Context
@@ -22,33 +29,201 @@ func NewProtoParser(ctx Context) *ProtoParser {
 
 // Parse method parse protobuf source
 func (p ProtoParser) Parse(reader io.Reader) ([]*Struct, error) {
+	file, err := p.ParseFile(reader)
+	if err != nil {
+		return nil, err
+	}
+	return file.Structs, nil
+}
+
+// ParseFile method parse protobuf source to [File], it contains the services
+func (p ProtoParser) ParseFile(reader io.Reader) (*File, error) {
 	got, err := protoparser.Parse(reader)
 	if err != nil {
 		return nil, err
 	}
 
-	res := make([]*Struct, 0, len(got.ProtoBody))
+	types := make(map[string]Type)
+	imported, err := p.parseImports(got, types, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{
+		Structs: imported,
+	}
+	p.visit(got, types, file)
+	return file, nil
+}
+
+func (p ProtoParser) visit(got *parser.Proto, types map[string]Type, file *File) {
+	collectProtoTypes(protoPackage(got), "", got.ProtoBody, types)
+
+	if got.Syntax != nil {
+		got.Syntax.Accept(newProtoVisitor(p.ctx, file, "", types))
+	}
 	for _, pb := range got.ProtoBody {
-		v := newProtoVisitor(p.ctx)
+		v := newProtoVisitor(p.ctx, file, "", types)
 		pb.Accept(v)
-		if v.Struct != nil {
-			res = append(res, v.Struct)
+		file.Structs = append(file.Structs, v.Structs()...)
+		if v.Service != nil {
+			file.Services = append(file.Services, v.Service)
+		}
+	}
+}
+
+// parseImports parse the files imported by got, the files are searched in
+// [Context.ImportPaths], the file which can not be found is skipped, and the
+// types in it are kept as the opaque names. The well-known types are not
+// loaded, they are mapped by [protoVisitor.type2Type]
+func (p ProtoParser) parseImports(got *parser.Proto, types map[string]Type, loaded map[string]bool) ([]*Struct, error) {
+	res := make([]*Struct, 0)
+	for _, body := range got.ProtoBody {
+		i, ok := body.(*parser.Import)
+		if !ok {
+			continue
+		}
+
+		location := strings.Trim(i.Location, `"'`)
+		if strings.HasPrefix(location, protoWellKnownDir) {
+			continue
 		}
+
+		filename := p.findImport(location)
+		if filename == "" || loaded[filename] {
+			continue
+		}
+		loaded[filename] = true
+
+		imported, err := parseProtoFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		structs, err := p.parseImports(imported, types, loaded)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, structs...)
+
+		// the package and the services of the imported file are dropped
+		file := &File{}
+		p.visit(imported, types, file)
+		res = append(res, file.Structs...)
 	}
 	return res, nil
 }
 
+func (p ProtoParser) findImport(location string) string {
+	for _, dir := range p.ctx.ImportPaths {
+		filename := filepath.Join(dir, location)
+		if info, err := os.Stat(filename); err == nil && !info.IsDir() {
+			return filename
+		}
+	}
+	return ""
+}
+
+func parseProtoFile(filename string) (*parser.Proto, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	got, err := protoparser.Parse(f, protoparser.WithFilename(filename))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s failed: %w", filename, err)
+	}
+	return got, nil
+}
+
+func protoPackage(got *parser.Proto) string {
+	for _, body := range got.ProtoBody {
+		if p, ok := body.(*parser.Package); ok {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// collectProtoTypes collect all the messages and enums declared in the
+// bodies, the key of types is the full name, such as `Outer.Inner`, the full
+// name with the package, such as `pkg.Outer.Inner`, is also recorded
+func collectProtoTypes(pkg, scope string, bodies []parser.Visitee, types map[string]Type) {
+	add := func(fullName string, t Type) {
+		types[fullName] = t
+		if pkg != "" {
+			types[protoFullName(pkg, fullName)] = t
+		}
+	}
+
+	for _, body := range bodies {
+		switch b := body.(type) {
+		case *parser.Message:
+			fullName := protoFullName(scope, b.MessageName)
+			add(fullName, &StructLikeType{
+				Name: protoQualifiedName(fullName),
+			})
+			collectProtoTypes(pkg, fullName, b.MessageBody, types)
+		case *parser.Enum:
+			fullName := protoFullName(scope, b.EnumName)
+			add(fullName, &EnumType{
+				Name: protoQualifiedName(fullName),
+			})
+		}
+	}
+}
+
+func protoFullName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// protoQualifiedName convert the full name of a nested type to a flat name,
+// such as `Outer.Inner` to `Outer_Inner`
+func protoQualifiedName(fullName string) string {
+	return strings.ReplaceAll(fullName, ".", "_")
+}
+
 type protoVisitor struct {
 	Struct *Struct
+	Oneofs []*Struct
+	Nested []*Struct
 	ctx    Context
+
+	Service *Service
+
+	// file is the file to record the file level declarations
+	file *File
+	// scope is the full name of the enclosing message
+	scope string
+	types map[string]Type
 }
 
-func newProtoVisitor(ctx Context) *protoVisitor {
+func newProtoVisitor(ctx Context, file *File, scope string, types map[string]Type) *protoVisitor {
 	return &protoVisitor{
-		ctx: ctx,
+		ctx:   ctx,
+		file:  file,
+		scope: scope,
+		types: types,
 	}
 }
 
+// Structs get the structs visited, the nested types and the oneof unions are
+// referenced by the struct, put them ahead
+func (v *protoVisitor) Structs() []*Struct {
+	res := make([]*Struct, 0, len(v.Nested)+len(v.Oneofs)+1)
+	res = append(res, v.Nested...)
+	res = append(res, v.Oneofs...)
+	if v.Struct != nil {
+		res = append(res, v.Struct)
+	}
+	return res
+}
+
 func (v *protoVisitor) VisitComment(c *parser.Comment) {
 }
 
@@ -59,7 +234,7 @@ func (v *protoVisitor) VisitEmptyStatement(s *parser.EmptyStatement) bool {
 func (v *protoVisitor) VisitEnum(e *parser.Enum) bool {
 	v.Struct = &Struct{
 		Type: &EnumType{
-			Name: e.EnumName,
+			Name: protoQualifiedName(protoFullName(v.scope, e.EnumName)),
 		},
 		Comment: v.comment2Comment(e.Comments, e.InlineCommentBehindLeftCurly),
 	}
@@ -87,6 +262,7 @@ func (v *protoVisitor) VisitExtensions(e *parser.Extensions) bool {
 
 func (v *protoVisitor) VisitField(f *parser.Field) bool {
 	fieldNumber, _ := strconv.ParseInt(f.FieldNumber, 10, 64)
+	_, isWrapper := v.wrapper2Type(f.Type)
 	v.Struct.Members = append(v.Struct.Members, &Member{
 		Field: f.FieldName,
 		Type: func() Type {
@@ -97,8 +273,9 @@ func (v *protoVisitor) VisitField(f *parser.Field) bool {
 			}
 			return v.type2Type(f.Type)
 		}(),
-		Index:    int(fieldNumber),
-		Optional: f.IsOptional,
+		Index: int(fieldNumber),
+		// the wrapper type is a nullable scalar
+		Optional: f.IsOptional || isWrapper && !f.IsRepeated,
 		Comment:  v.comment2Comment(f.Comments, f.InlineComment),
 	})
 	return true
@@ -128,32 +305,88 @@ func (v *protoVisitor) VisitMapField(f *parser.MapField) bool {
 }
 
 func (v *protoVisitor) VisitMessage(m *parser.Message) bool {
+	v.scope = protoFullName(v.scope, m.MessageName)
 	v.Struct = &Struct{
 		Type: &StructLikeType{
-			Name:   m.MessageName,
+			Name:   protoQualifiedName(v.scope),
 			Source: SLSStruct,
 		},
 		Comment: v.comment2Comment(m.Comments, m.InlineCommentBehindLeftCurly),
 	}
-	return true
+
+	for _, body := range m.MessageBody {
+		switch body.(type) {
+		case *parser.Message, *parser.Enum:
+			// visit the nested type with a new visitor, it is a separate struct
+			nested := newProtoVisitor(v.ctx, v.file, v.scope, v.types)
+			body.Accept(nested)
+			v.Nested = append(v.Nested, nested.Structs()...)
+		default:
+			body.Accept(v)
+		}
+	}
+
+	// the body has been visited
+	return false
 }
 
 func (v *protoVisitor) VisitOneof(o *parser.Oneof) bool {
-	return true
+	structName := v.Struct.Type.(*StructLikeType).Name + "_" + camel(o.OneofName)
+	union := &Struct{
+		Type: &StructLikeType{
+			Name:   structName,
+			Source: SLSUnion,
+		},
+		Comment: v.comment2Comment(o.Comments, o.InlineCommentBehindLeftCurly),
+		Oneof:   true,
+	}
+
+	for _, f := range o.OneofFields {
+		fieldNumber, _ := strconv.ParseInt(f.FieldNumber, 10, 64)
+		union.Members = append(union.Members, &Member{
+			Field:    f.FieldName,
+			Type:     v.type2Type(f.Type),
+			Index:    int(fieldNumber),
+			Optional: true,
+			Comment:  v.comment2Comment(f.Comments, f.InlineComment),
+		})
+	}
+
+	// oneof has no field number, use the number of the first field in it
+	index := 0
+	if len(union.Members) > 0 {
+		index = union.Members[0].Index
+	}
+
+	v.Struct.Members = append(v.Struct.Members, &Member{
+		Field: o.OneofName,
+		Type: &StructLikeType{
+			Name: structName,
+		},
+		Index:   index,
+		Comment: union.Comment,
+		Oneof:   union,
+	})
+	v.Oneofs = append(v.Oneofs, union)
+
+	// the oneof fields have been processed
+	return false
 }
 
 func (v *protoVisitor) VisitOneofField(f *parser.OneofField) bool {
-	// fmt.Printf("%+v\n", f)
 	return true
 }
 
 func (v *protoVisitor) VisitOption(o *parser.Option) bool {
-	// fmt.Printf("%+v\n", o)
+	// only the file level option is recorded
+	if v.Struct == nil && v.Service == nil && o.OptionName == StrGoPackage {
+		v.file.GoPackage = strings.Trim(o.Constant, `"'`)
+	}
 	return true
 }
 
 func (v *protoVisitor) VisitPackage(p *parser.Package) bool {
-	// fmt.Printf("%+v\n", p)
+	v.file.Package = p.Name
 	return true
 }
 
@@ -163,17 +396,32 @@ func (v *protoVisitor) VisitReserved(r *parser.Reserved) bool {
 }
 
 func (v *protoVisitor) VisitRPC(rpc *parser.RPC) bool {
-	// fmt.Printf("%+v\n", rpc)
+	method := &Method{
+		Name:    rpc.RPCName,
+		Comment: v.comment2Comment(rpc.Comments, rpc.InlineComment),
+	}
+	if rpc.RPCRequest != nil {
+		method.Request = v.rpcType2Type(rpc.RPCRequest.MessageType)
+		method.RequestStream = rpc.RPCRequest.IsStream
+	}
+	if rpc.RPCResponse != nil {
+		method.Response = v.rpcType2Type(rpc.RPCResponse.MessageType)
+		method.ResponseStream = rpc.RPCResponse.IsStream
+	}
+	v.Service.Methods = append(v.Service.Methods, method)
 	return true
 }
 
 func (v *protoVisitor) VisitService(s *parser.Service) bool {
-	// fmt.Printf("%+v\n", s)
+	v.Service = &Service{
+		Name:    s.ServiceName,
+		Comment: v.comment2Comment(s.Comments, s.InlineCommentBehindLeftCurly),
+	}
 	return true
 }
 
 func (v *protoVisitor) VisitSyntax(s *parser.Syntax) bool {
-	// fmt.Printf("%+v\n", s)
+	v.file.Syntax = s.ProtobufVersion
 	return true
 }
 
@@ -205,12 +453,90 @@ func (v *protoVisitor) type2Type(str string) Type {
 		return StringVal
 	case StrBytes:
 		return BinaryVal
-	case StrPbAny:
+	case StrPbAny, StrPbValue:
 		return AnyVal
+	case StrPbTimestamp:
+		return TimestampVal
+	case StrPbDuration:
+		return DurationVal
+	case StrPbStruct:
+		return &MapType{
+			Key:   StringVal,
+			Value: AnyVal,
+		}
+	case StrPbListValue:
+		return &ArrayType{
+			ChildType: AnyVal,
+		}
+	}
+	if t, ok := v.wrapper2Type(str); ok {
+		return t
+	}
+	return v.resolveType(str)
+}
+
+// wrapper2Type convert the well-known wrapper type, such as
+// `google.protobuf.StringValue`, to the scalar type it wraps
+func (v *protoVisitor) wrapper2Type(str string) (Type, bool) {
+	for scalar, wrapper := range protoWrappers {
+		if str == wrapper {
+			return v.type2Type(scalar), true
+		}
 	}
+	return nil, false
+}
+
+// rpcType2Type convert the message type of rpc to [Type], the
+// `google.protobuf.Empty` means there is no message
+func (v *protoVisitor) rpcType2Type(str string) Type {
+	if str == StrPbEmpty {
+		return nil
+	}
+	return v.type2Type(str)
+}
+
+// resolveType find the message or enum type by the name, the name is looked
+// up from the innermost scope to the outermost scope as protobuf does
+func (v *protoVisitor) resolveType(name string) Type {
+	if strings.HasPrefix(name, ".") {
+		if t, ok := v.types[strings.TrimPrefix(name, ".")]; ok {
+			return v.copyType(t)
+		}
+	}
+
+	scope := v.scope
+	for {
+		if t, ok := v.types[protoFullName(scope, name)]; ok {
+			return v.copyType(t)
+		}
+		if scope == "" {
+			break
+		}
+		index := strings.LastIndex(scope, ".")
+		if index < 0 {
+			scope = ""
+		} else {
+			scope = scope[:index]
+		}
+	}
+
 	return &StructLikeType{
-		Name: str,
+		Name: name,
+	}
+}
+
+func (v *protoVisitor) copyType(t Type) Type {
+	switch t := t.(type) {
+	case *StructLikeType:
+		return &StructLikeType{
+			Name: t.Name,
+		}
+	case *EnumType:
+		return &EnumType{
+			Name: t.Name,
+		}
 	}
+	return t
 }
 
 func (v *protoVisitor) comment2Comment(beginComments []*parser.Comment, inlineComment *parser.Comment) Comment {
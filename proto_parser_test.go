@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -228,6 +230,317 @@ message ErrorStatus {
 			},
 			wantErr: false,
 		},
+		{
+			name: "oneof",
+			init: func(t *testing.T) ProtoParser {
+				return *NewProtoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+
+message SampleMessage {
+  // choice
+  oneof test_oneof {
+    string name = 4;
+    ErrorStatus sub_message = 9; // sub
+  }
+}
+`)),
+				}
+			},
+			want1: func() []*Struct {
+				union := &Struct{
+					Type: &StructLikeType{
+						Name:   "SampleMessage_TestOneof",
+						Source: SLSUnion,
+					},
+					Comment: Comment{
+						BeginningComments: []string{"// choice"},
+					},
+					Oneof: true,
+					Members: []*Member{
+						{
+							Field:    "name",
+							Type:     StringVal,
+							Index:    4,
+							Optional: true,
+						},
+						{
+							Field: "sub_message",
+							Type: &StructLikeType{
+								Name: "ErrorStatus",
+							},
+							Index:    9,
+							Optional: true,
+							Comment: Comment{
+								InlineComment: "// sub",
+							},
+						},
+					},
+				}
+				return []*Struct{
+					union,
+					{
+						Type: &StructLikeType{
+							Name:   "SampleMessage",
+							Source: SLSStruct,
+						},
+						Members: []*Member{
+							{
+								Field: "test_oneof",
+								Type: &StructLikeType{
+									Name: "SampleMessage_TestOneof",
+								},
+								Index: 4,
+								Comment: Comment{
+									BeginningComments: []string{"// choice"},
+								},
+								Oneof: union,
+							},
+						},
+					},
+				}
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "nested",
+			init: func(t *testing.T) ProtoParser {
+				return *NewProtoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+
+message Outer {
+  message Inner {
+    enum Kind {
+      A = 0;
+    }
+    Kind kind = 1;
+  }
+  Inner inner = 1;
+  repeated Outer.Inner.Kind kinds = 2;
+  .Outer.Inner other = 3;
+}
+`)),
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &EnumType{
+						Name: "Outer_Inner_Kind",
+					},
+					Members: []*Member{
+						{
+							Field: "A",
+							Type: &EnumType{
+								Name: "Outer_Inner_Kind",
+							},
+							Index: 0,
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Outer_Inner",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "kind",
+							Type: &EnumType{
+								Name: "Outer_Inner_Kind",
+							},
+							Index: 1,
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Outer",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "inner",
+							Type: &StructLikeType{
+								Name: "Outer_Inner",
+							},
+							Index: 1,
+						},
+						{
+							Field: "kinds",
+							Type: &ArrayType{
+								ChildType: &EnumType{
+									Name: "Outer_Inner_Kind",
+								},
+							},
+							Index: 2,
+						},
+						{
+							Field: "other",
+							Type: &StructLikeType{
+								Name: "Outer_Inner",
+							},
+							Index: 3,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "import",
+			init: func(t *testing.T) ProtoParser {
+				dir := t.TempDir()
+				assert.NoError(t, os.MkdirAll(filepath.Join(dir, "common"), 0755))
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "common", "user.proto"), []byte(`
+syntax = "proto3";
+package common;
+
+message User {
+  string name = 1;
+}
+`), 0644))
+				return *NewProtoParser(Context{
+					ImportPaths: []string{dir},
+				})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+package api;
+
+import "common/user.proto";
+import "not/exist.proto";
+
+message Event {
+  common.User user = 1;
+  .common.User owner = 2;
+  missing.Item item = 3;
+}
+`)),
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "name",
+							Type:  StringVal,
+							Index: 1,
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "Event",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "user",
+							Type: &StructLikeType{
+								Name: "User",
+							},
+							Index: 1,
+						},
+						{
+							Field: "owner",
+							Type: &StructLikeType{
+								Name: "User",
+							},
+							Index: 2,
+						},
+						{
+							Field: "item",
+							Type: &StructLikeType{
+								Name: "missing.Item",
+							},
+							Index: 3,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "well-known types",
+			init: func(t *testing.T) ProtoParser {
+				return *NewProtoParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				return args{
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/duration.proto";
+import "google/protobuf/struct.proto";
+import "google/protobuf/wrappers.proto";
+
+message Event {
+  google.protobuf.Timestamp at = 1;
+  google.protobuf.Duration ttl = 2;
+  google.protobuf.Struct extra = 3;
+  google.protobuf.StringValue note = 4;
+  repeated google.protobuf.Int64Value ids = 5;
+}
+`)),
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "Event",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "at",
+							Type:  TimestampVal,
+							Index: 1,
+						},
+						{
+							Field: "ttl",
+							Type:  DurationVal,
+							Index: 2,
+						},
+						{
+							Field: "extra",
+							Type: &MapType{
+								Key:   StringVal,
+								Value: AnyVal,
+							},
+							Index: 3,
+						},
+						{
+							Field:    "note",
+							Type:     StringVal,
+							Index:    4,
+							Optional: true,
+						},
+						{
+							Field: "ids",
+							Type: &ArrayType{
+								ChildType: Int64Val,
+							},
+							Index: 5,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,13 +1,20 @@
 package st2
 
 import (
+	"sort"
 	"strings"
 )
 
 type rawNode struct {
-	Field       string
-	Type        Type
-	Children    []*rawNode
+	Field    string
+	Type     Type
+	Children []*rawNode
+	Optional bool
+	// Null reports whether only null value has been seen, the type of the
+	// node is unknown
+	Null bool
+	// Format is the detected format of the string value
+	Format      string
 	fingerprint string
 }
 
@@ -23,6 +30,14 @@ func (node *rawNode) Fingerprint() string {
 }
 
 func (node *rawNode) fingerprintHelper() string {
+	if node.Optional {
+		// the optional node is different from the required one
+		return "?" + node.fingerprintType()
+	}
+	return node.fingerprintType()
+}
+
+func (node *rawNode) fingerprintType() string {
 	switch node.Type {
 	case ArrayVal:
 		child := "null"
@@ -38,6 +53,9 @@ func (node *rawNode) fingerprintHelper() string {
 		}
 		return "{" + strings.Join(children, ";") + "}"
 	default:
+		if node.Format != "" {
+			return node.Type.Json() + "(" + node.Format + ")"
+		}
 		return node.Type.Json()
 	}
 }
@@ -55,3 +73,113 @@ func (l NodeList) Less(i, j int) bool {
 func (l NodeList) Swap(i, j int) {
 	l[i], l[j] = l[j], l[i]
 }
+
+// mergeNode merge the shape of two nodes which have the same field.
+// The child which is absent in one of the nodes becomes optional,
+// a null value takes the type of the other node and becomes optional,
+// integer and float are widened to float, other conflict types become any.
+func mergeNode(a, b *rawNode) *rawNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if a.Null && !b.Null {
+		b.Optional = true
+		return b
+	}
+	if b.Null && !a.Null {
+		a.Optional = true
+		return a
+	}
+
+	switch {
+	case a.Type == b.Type && a.Format == b.Format:
+	case isStringVal(a.Type) && isStringVal(b.Type):
+		// the formats are different, fallback to string
+		a.Type = StringVal
+		a.Format = ""
+	case isIntegerVal(a.Type) && isIntegerVal(b.Type):
+		a.Type = Int64Val
+	case isNumberVal(a.Type) && isNumberVal(b.Type):
+		a.Type = Float64Val
+	default:
+		return &rawNode{
+			Field:    a.Field,
+			Type:     AnyVal,
+			Optional: a.Optional || b.Optional,
+		}
+	}
+
+	a.Optional = a.Optional || b.Optional
+
+	switch a.Type {
+	case StructLikeVal:
+		a.Children = mergeChildren(a.Children, b.Children)
+	case ArrayVal:
+		a.Children = []*rawNode{mergeElement(firstChild(a), firstChild(b))}
+	}
+	return a
+}
+
+// mergeElement merge the elements of arrays, the element of an array is
+// never optional
+func mergeElement(a, b *rawNode) *rawNode {
+	element := mergeNode(a, b)
+	if element != nil {
+		element.Optional = false
+	}
+	return element
+}
+
+func mergeChildren(a, b []*rawNode) []*rawNode {
+	m := make(map[string]*rawNode, len(b))
+	for _, child := range b {
+		m[child.Field] = child
+	}
+
+	children := make([]*rawNode, 0, len(a)+len(b))
+	for _, child := range a {
+		other, ok := m[child.Field]
+		if !ok {
+			child.Optional = true
+			children = append(children, child)
+			continue
+		}
+		delete(m, child.Field)
+		children = append(children, mergeNode(child, other))
+	}
+	for _, child := range b {
+		if _, ok := m[child.Field]; ok {
+			child.Optional = true
+			children = append(children, child)
+		}
+	}
+	sort.Sort(NodeList(children))
+	return children
+}
+
+func firstChild(node *rawNode) *rawNode {
+	if len(node.Children) == 0 {
+		return nil
+	}
+	return node.Children[0]
+}
+
+func isIntegerVal(t Type) bool {
+	switch t {
+	case Int8Val, Int16Val, Int32Val, Int64Val, Uint8Val, Uint16Val, Uint32Val, Uint64Val:
+		return true
+	}
+	return false
+}
+
+func isStringVal(t Type) bool {
+	return t == StringVal || t == TimestampVal || t == BinaryVal
+}
+
+func isNumberVal(t Type) bool {
+	return isIntegerVal(t) || t == Float32Val || t == Float64Val
+}
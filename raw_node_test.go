@@ -134,3 +134,96 @@ func TestRawNode_Fingerprint(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeNode(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *rawNode
+		b    *rawNode
+
+		want1 string
+	}{
+		{
+			name:  "nil",
+			a:     nil,
+			b:     &rawNode{Type: StringVal},
+			want1: "string",
+		},
+		{
+			name:  "integer",
+			a:     &rawNode{Type: Int8Val},
+			b:     &rawNode{Type: Uint64Val},
+			want1: "number",
+		},
+		{
+			name:  "widen to float",
+			a:     &rawNode{Type: Int64Val},
+			b:     &rawNode{Type: Float32Val},
+			want1: "number",
+		},
+		{
+			name:  "conflict",
+			a:     &rawNode{Type: Int64Val},
+			b:     &rawNode{Type: StringVal, Optional: true},
+			want1: "?null",
+		},
+		{
+			name:  "null first",
+			a:     &rawNode{Type: AnyVal, Null: true},
+			b:     &rawNode{Type: StringVal},
+			want1: "?string",
+		},
+		{
+			name:  "null last",
+			a:     &rawNode{Type: BoolVal},
+			b:     &rawNode{Type: AnyVal, Null: true},
+			want1: "?bool",
+		},
+		{
+			name:  "conflict with concrete type",
+			a:     &rawNode{Type: AnyVal},
+			b:     &rawNode{Type: BoolVal},
+			want1: "null",
+		},
+		{
+			name: "struct",
+			a: &rawNode{
+				Type: StructLikeVal,
+				Children: []*rawNode{
+					{Field: "b", Type: StringVal},
+					{Field: "c", Type: BoolVal},
+				},
+			},
+			b: &rawNode{
+				Type: StructLikeVal,
+				Children: []*rawNode{
+					{Field: "a", Type: StringVal},
+					{Field: "b", Type: StringVal},
+				},
+			},
+			want1: "{a:?string;b:string;c:?bool}",
+		},
+		{
+			name: "array",
+			a: &rawNode{
+				Type:     ArrayVal,
+				Children: []*rawNode{{Type: AnyVal, Null: true}},
+			},
+			b: &rawNode{
+				Type:     ArrayVal,
+				Children: []*rawNode{{Type: Float64Val}},
+			},
+			want1: "[:number]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got1 := mergeNode(tt.a, tt.b).Fingerprint()
+
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("mergeNode got1 = %v, want1: %v", got1, tt.want1)
+			}
+		})
+	}
+}
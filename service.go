@@ -0,0 +1,159 @@
+package st2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Method is a rpc method of [Service]
+type Method struct {
+	Name string
+	// Request is the request type, nil if there is no request
+	Request Type
+	// RequestName is the argument name of the request
+	RequestName string
+	// Response is the response type, nil if there is no response
+	Response       Type
+	RequestStream  bool
+	ResponseStream bool
+	// Throws is the exceptions thrown by the method, such as thrift
+	// `throws (1: NotFound e)`
+	Throws  []*Member
+	Comment Comment
+}
+
+// NameCamel get a camel type method name
+func (m Method) NameCamel() string {
+	return camel(m.Name)
+}
+
+// GoParams get the parameters of the golang interface method
+func (m Method) GoParams() string {
+	params := "ctx context.Context"
+	if m.Request == nil {
+		return params
+	}
+	if m.RequestStream {
+		return fmt.Sprintf("%s, %s <-chan %s", params, m.requestName(), m.Request.Go())
+	}
+	return fmt.Sprintf("%s, %s %s", params, m.requestName(), m.Request.Go())
+}
+
+// GoResults get the results of the golang interface method
+func (m Method) GoResults() string {
+	if m.Response == nil {
+		return "error"
+	}
+	if m.ResponseStream {
+		return fmt.Sprintf("(<-chan %s, error)", m.Response.Go())
+	}
+	return fmt.Sprintf("(%s, error)", m.Response.Go())
+}
+
+// ProtoRequest get the request of the proto rpc
+func (m Method) ProtoRequest() string {
+	return protoRPCType(m.Request, m.RequestStream)
+}
+
+// ProtoResponse get the response of the proto rpc
+func (m Method) ProtoResponse() string {
+	return protoRPCType(m.Response, m.ResponseStream)
+}
+
+// ThriftArgs get the arguments of the thrift function
+func (m Method) ThriftArgs() string {
+	if m.Request == nil {
+		return ""
+	}
+	return fmt.Sprintf("1: %s %s", m.Request.Thrift(), m.requestName())
+}
+
+// ThriftResponse get the response type of the thrift function
+func (m Method) ThriftResponse() string {
+	if m.Response == nil {
+		return StrVoid
+	}
+	return m.Response.Thrift()
+}
+
+// ThriftThrows get the throws clause of the thrift function with a leading
+// space
+func (m Method) ThriftThrows() string {
+	if len(m.Throws) == 0 {
+		return ""
+	}
+	items := make([]string, 0, len(m.Throws))
+	for _, t := range m.Throws {
+		items = append(items, fmt.Sprintf("%d: %s %s", t.Index, t.Thrift(), t.Field))
+	}
+	return " throws (" + strings.Join(items, ", ") + ")"
+}
+
+// ThriftAnnotation get the streaming annotation of the thrift function,
+// it follows the kitex convention
+func (m Method) ThriftAnnotation() string {
+	mode := streamingMode(m.RequestStream, m.ResponseStream)
+	if mode == "" {
+		return ""
+	}
+	return fmt.Sprintf(` (%s="%s")`, ThriftStreamingMode, mode)
+}
+
+func (m Method) requestName() string {
+	if m.RequestName == "" {
+		return "req"
+	}
+	return m.RequestName
+}
+
+// Service is a parsed result which contains the source service data
+type Service struct {
+	Name    string
+	Methods []*Method
+	Comment Comment
+}
+
+// NameCamel get a camel type service name
+func (s Service) NameCamel() string {
+	return camel(s.Name)
+}
+
+// protoWrappers map the scalar types to the well-known wrapper messages, the
+// request and response of rpc must be a message
+var protoWrappers = map[string]string{
+	StrDouble: "google.protobuf.DoubleValue",
+	StrFloat:  "google.protobuf.FloatValue",
+	StrInt32:  "google.protobuf.Int32Value",
+	StrInt64:  "google.protobuf.Int64Value",
+	StrUint32: "google.protobuf.UInt32Value",
+	StrUint64: "google.protobuf.UInt64Value",
+	StrBool:   "google.protobuf.BoolValue",
+	StrString: "google.protobuf.StringValue",
+	StrBytes:  "google.protobuf.BytesValue",
+}
+
+func protoRPCType(t Type, stream bool) string {
+	name := StrPbEmpty
+	if t != nil {
+		name = t.Proto()
+	}
+	if wrapper, ok := protoWrappers[name]; ok {
+		name = wrapper
+	}
+	if stream {
+		return "stream " + name
+	}
+	return name
+}
+
+func streamingMode(requestStream, responseStream bool) string {
+	switch {
+	case requestStream && responseStream:
+		return StreamingBidirectional
+	case requestStream:
+		return StreamingClient
+	case responseStream:
+		return StreamingServer
+	}
+	return ""
+}
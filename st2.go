@@ -3,6 +3,7 @@ package st2
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"text/template"
 )
@@ -27,10 +28,51 @@ func Convert(ctx Context, reader io.Reader, writer io.Writer) error {
 		return errors.New("Can not found template")
 	}
 
-	structs, err := parse.Parse(reader)
+	file, err := parseFile(parse, reader)
+	if err != nil {
+		return err
+	}
+	return render(ctx, tmpl, file, writer)
+}
+
+// ConvertPackage is a wrap function parse the source packages matched by the
+// pattern and write the output to writer, the pattern is a directory, or a
+// directory followed by `/...` to match all the sub directories. It only
+// works for the source whose parser implements [ParsePackage], such as go.
+func ConvertPackage(ctx Context, pattern string, writer io.Writer) error {
+	if writer == nil {
+		return errors.New("writer is nil")
+	}
+
+	parse := CreateParser(ctx)
+	if parse == nil {
+		return errors.New("Can not found parser")
+	}
+	p, ok := parse.(ParsePackage)
+	if !ok {
+		return fmt.Errorf("Can not parse package of %s", ctx.Src)
+	}
+
+	tmpl := CreateTmpl(ctx)
+	if tmpl == "" {
+		return errors.New("Can not found template")
+	}
+
+	file, err := p.ParsePackage(pattern)
 	if err != nil {
 		return err
 	}
+	return render(ctx, tmpl, file, writer)
+}
+
+// render write the file to writer with the template tmpl
+func render(ctx Context, tmpl string, file *File, writer io.Writer) error {
+	if ctx.Package != "" {
+		file.Package = ctx.Package
+	}
+	if ctx.GoPackage != "" {
+		file.GoPackage = ctx.GoPackage
+	}
 
 	t, err := template.New("st2").Parse(tmpl)
 	if err != nil {
@@ -38,7 +80,7 @@ func Convert(ctx Context, reader io.Reader, writer io.Writer) error {
 	}
 
 	b := new(bytes.Buffer)
-	err = t.Execute(b, structs)
+	err = t.Execute(b, file)
 	if err != nil {
 		return err
 	}
@@ -50,3 +92,17 @@ func Convert(ctx Context, reader io.Reader, writer io.Writer) error {
 	_, err = writer.Write(data)
 	return err
 }
+
+func parseFile(parse Parse, reader io.Reader) (*File, error) {
+	if p, ok := parse.(ParseFile); ok {
+		return p.ParseFile(reader)
+	}
+
+	structs, err := parse.Parse(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &File{
+		Structs: structs,
+	}, nil
+}
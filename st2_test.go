@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -179,6 +181,57 @@ type Root struct {
 	H  any      ` + "`" + `json:"h,omitempty"` + "`" + `
 }
 
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to go with array elements merged",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src:   "json",
+						Dst:   "go",
+						Merge: true,
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"items": [{"x": null}, {"x": 1}]}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`type Items struct {
+	X *int64 ` + "`" + `json:"x,omitempty"` + "`" + `
+}
+
+type Root struct {
+	Items []*Items ` + "`" + `json:"items,omitempty"` + "`" + `
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to go with array elements not merged",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"items": [{"x": null}, {"x": 1}]}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`type Items struct {
+	X any ` + "`" + `json:"x,omitempty"` + "`" + `
+}
+
+type Root struct {
+	Items []*Items ` + "`" + `json:"items,omitempty"` + "`" + `
+}
+
 `),
 			wantErr: false,
 		},
@@ -223,7 +276,11 @@ type Root struct {
 				a.writer = a.buffer
 				return a
 			},
-			wantData: []byte(`message A {
+			wantData: []byte(`syntax = "proto3";
+
+import "google/protobuf/any.proto";
+
+message A {
     int64 b = 1; 
     string c = 2; 
 }
@@ -396,10 +453,13 @@ message SampleMessage {
 				a.writer = a.buffer
 				return a
 			},
-			wantData: []byte(`// EEEE
+			wantData: []byte(`package st2
+
+// EEEE
 type Eeee int // EEEE
 
 const (
+	// A
 	A Eeee = 0 // a
 )
 
@@ -429,9 +489,14 @@ type ErrorStatus struct {
 	Details []any
 }
 
-type SampleMessage struct {
+type SampleMessage_TestOneof struct {
+	Name       *string
+	SubMessage *ErrorStatus
 }
 
+type SampleMessage struct {
+	TestOneof *SampleMessage_TestOneof
+}
 `),
 			wantErr: false,
 		},
@@ -564,8 +629,11 @@ message SampleMessage {
 				a.writer = a.buffer
 				return a
 			},
-			wantData: []byte(`// EEEE
-enum Eeee { // EEEE  
+			wantData: []byte(`namespace go tenfyzhong.st2
+
+// EEEE
+enum Eeee { // EEEE 
+    // A
     A = 0; // a
 }
 
@@ -595,7 +663,13 @@ struct ErrorStatus {
     2: list<binary> details, 
 }
 
+union SampleMessage_TestOneof {
+    4: string name, 
+    9: ErrorStatus sub_message, 
+}
+
 struct SampleMessage {
+    4: SampleMessage_TestOneof test_oneof, 
 }
 
 `),
@@ -661,7 +735,10 @@ const (
 	B EEE = 2
 )
 
+// hhhh
 type SS struct {
+	// aa
+	// ss
 	A *bool
 	B int8
 	C int16
@@ -682,7 +759,7 @@ type AAA struct {
 type BBB struct {
 	B1    int16
 	B2    int32
-	E     *EEE
+	E     EEE
 	Mapab map[*AAA]*BBB
 	Seta  map[*AAA]bool
 	Listb []*BBB
@@ -749,12 +826,16 @@ union UUU {
 				a.writer = a.buffer
 				return a
 			},
-			wantData: []byte(`enum EEE { 
-    A = 1;  
+			wantData: []byte(`enum EEE {
+    EEE_UNSPECIFIED = 0; 
+    A = 1; 
     B = 2; 
 }
 
+// hhhh
 message SS {
+    // aa
+    // ss
     bool a = 1; 
     int32 b = 2; 
     int32 c = 3; 
@@ -861,9 +942,15 @@ type SampleMessage struct {
 				a.writer = a.buffer
 				return a
 			},
-			wantData: []byte(`enum Eeee { 
-    EEEA = 0; // comment EEEA Eeee inline 
-    EEEB = 1; // a 
+			wantData: []byte(`syntax = "proto3";
+
+package main;
+
+enum Eeee {
+    // comment EEEA Eeee block
+    // comment EEEA Eeee block
+    EEEA = 0; // comment EEEA Eeee inline
+    EEEB = 1; // a
     EEEC = 3; // a
 }
 
@@ -973,9 +1060,13 @@ type SampleMessage struct {
 				a.writer = a.buffer
 				return a
 			},
-			wantData: []byte(`enum Eeee { 
-    EEEA = 0; // comment EEEA Eeee inline 
-    EEEB = 1; // a 
+			wantData: []byte(`namespace * main
+
+enum Eeee {
+    // comment EEEA Eeee block
+    // comment EEEA Eeee block
+    EEEA = 0; // comment EEEA Eeee inline
+    EEEB = 1; // a
     EEEC = 3; // a
 }
 
@@ -985,7 +1076,7 @@ struct Aaa {
     // comment Aaa a
     1: list<i32> a, // comment Aaa a inline
     2: i64 b, 
-    3: string c, 
+    3: optional string c, 
     4: map<i64, string> mm, 
 }
 
@@ -1013,6 +1104,2731 @@ struct SampleMessage {
 `),
 			wantErr: false,
 		},
+		{
+			name: "thrift to typescript",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "typescript",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+enum EEE {
+    A = 1;
+    B = 2;
+}
+
+struct SS {
+    1: optional bool a,
+    2: byte b,
+    3: string c,
+    4: binary d,
+    5: map<i32, string> e,
+    6: optional list<i32> f,
+    7: set<string> g,
+    8: list<map<string, i64>> h,
+}
+
+struct BBB {
+    1: EEE e,
+    2: map<SS, BBB> mapab,
+    3: list<BBB> listb,
+}
+					`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`export enum EEE {
+    A = 1, 
+    B = 2, 
+}
+
+export interface SS {
+    a?: boolean; 
+    b: number; 
+    c: string; 
+    d: string; 
+    e: Record<number, string>; 
+    f?: number[]; 
+    g: string[]; 
+    h: Array<Record<string, number>>; 
+}
+
+export interface BBB {
+    e: EEE; 
+    mapab: Map<SS, BBB>; 
+    listb: BBB[]; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to ts",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "ts",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"a": {"b": 1, "c": ["hello"]}, "d": null}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`export interface A {
+    b: number; 
+    c: string[]; 
+}
+
+export interface Root {
+    a: A; 
+    d: any; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to ts with non-identifier keys",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "ts",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"a-b": 1, "ok": true, "1x": "s", "$v": null}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`export interface Root {
+    $v: any; 
+    "1x": string; 
+    "a-b": number; 
+    ok: boolean; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to ts with map keys",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "ts",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+struct S {
+    1: map<bool, string> flags,
+    2: map<i32, string> names,
+    3: map<string, i64> counts,
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`export interface S {
+    flags: Record<string, string>; 
+    names: Record<number, string>; 
+    counts: Record<string, number>; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to rust",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "rust",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+enum EEE {
+    A = 1;
+    B = 2;
+}
+
+struct SS {
+    1: optional bool a,
+    2: byte b,
+    3: string c,
+    4: binary d,
+    5: map<i32, string> e,
+    6: optional list<i32> f,
+    7: set<string> g,
+    8: list<map<string, i64>> h,
+}
+
+struct BBB {
+    1: EEE e,
+    2: map<string, BBB> mapab,
+    3: list<BBB> listb,
+}
+					`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`use serde::{Deserialize, Serialize};
+use serde_repr::{Deserialize_repr, Serialize_repr};
+use std::collections::{HashMap, HashSet};
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Serialize_repr, Deserialize_repr)]
+#[repr(i32)]
+pub enum Eee {
+    A = 1, 
+    B = 2, 
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Ss {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub a: Option<bool>, 
+    pub b: i8, 
+    pub c: String, 
+    pub d: Vec<u8>, 
+    pub e: HashMap<i32, String>, 
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub f: Option<Vec<i32>>, 
+    pub g: HashSet<String>, 
+    pub h: Vec<HashMap<String, i64>>, 
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Bbb {
+    pub e: Eee, 
+    pub mapab: HashMap<String, Bbb>, 
+    pub listb: Vec<Bbb>, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to rs",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "rs",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"userName": "a", "type": 1, "items": [{"k": true}], "d": null}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`use serde::{Deserialize, Serialize};
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Items {
+    pub k: bool, 
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Root {
+    pub d: serde_json::Value, 
+    pub items: Vec<Items>, 
+    pub r#type: i64, 
+    #[serde(rename = "userName")]
+    pub user_name: String, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to rust with string enum",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "rust",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Color string
+
+const (
+	ColorRed      Color = "red"
+	ColorDarkBlue Color = "dark-blue"
+)
+
+// Paint is a paint
+type Paint struct {
+	Color  Color
+	Alpha  *float32
+	Labels map[string]string
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`use serde::{Deserialize, Serialize};
+use std::collections::HashMap;
+
+#[derive(Debug, Clone, Copy, PartialEq, Eq, Serialize, Deserialize)]
+pub enum Color {
+    #[serde(rename = "red")]
+    Red, 
+    #[serde(rename = "dark-blue")]
+    DarkBlue, 
+}
+
+// Paint is a paint
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Paint {
+    pub color: Color, 
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub alpha: Option<f32>, 
+    pub labels: HashMap<String, String>, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to rust with recursive struct",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "rust",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+struct Node {
+    1: i32 value,
+    2: optional Node next,
+    3: list<Node> children,
+}
+
+struct A {
+    1: B b,
+}
+
+struct B {
+    1: optional A a,
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`use serde::{Deserialize, Serialize};
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Node {
+    pub value: i32, 
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub next: Option<Box<Node>>, 
+    pub children: Vec<Node>, 
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct A {
+    pub b: Box<B>, 
+}
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct B {
+    #[serde(skip_serializing_if = "Option::is_none")]
+    pub a: Option<Box<A>>, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to rust with non-identifier keys",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "rust",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"a-b": 1, "type": "x"}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`use serde::{Deserialize, Serialize};
+
+#[derive(Debug, Clone, Serialize, Deserialize)]
+pub struct Root {
+    #[serde(rename = "a-b")]
+    pub ab: i64, 
+    pub r#type: String, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to java",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "java",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+namespace * demo
+
+enum EEE {
+    A = 1;
+    B = 2;
+}
+
+struct SS {
+    1: optional bool a,
+    2: byte b,
+    3: string c,
+    4: binary d,
+    5: map<i32, string> e,
+    6: optional list<i32> f,
+    7: set<string> g,
+    8: list<map<string, i64>> h,
+}
+
+struct BBB {
+    1: EEE e,
+    2: map<string, BBB> mapab,
+    3: list<BBB> listb,
+}
+					`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`package demo;
+
+import com.fasterxml.jackson.annotation.JsonProperty;
+import com.fasterxml.jackson.annotation.JsonValue;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+
+enum Eee {
+    A(1), 
+    B(2), 
+    ;
+
+    private final int value;
+
+    Eee(int value) {
+        this.value = value;
+    }
+
+    @JsonValue
+    public int getValue() {
+        return value;
+    }
+}
+
+record Ss(
+    @JsonProperty("a") Boolean a, 
+    @JsonProperty("b") byte b, 
+    @JsonProperty("c") String c, 
+    @JsonProperty("d") byte[] d, 
+    @JsonProperty("e") Map<Integer, String> e, 
+    @JsonProperty("f") List<Integer> f, 
+    @JsonProperty("g") Set<String> g, 
+    @JsonProperty("h") List<Map<String, Long>> h 
+) {
+}
+
+record Bbb(
+    @JsonProperty("e") Eee e, 
+    @JsonProperty("mapab") Map<String, Bbb> mapab, 
+    @JsonProperty("listb") List<Bbb> listb 
+) {
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to java with string enum",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "java",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Color string
+
+const (
+	ColorRed      Color = "red"
+	ColorDarkBlue Color = "dark-blue"
+)
+
+// Paint is a paint
+type Paint struct {
+	Color  Color
+	Alpha  *float32
+	Labels map[string]string
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`package main;
+
+import com.fasterxml.jackson.annotation.JsonProperty;
+import java.util.Map;
+
+enum Color {
+    @JsonProperty("red")
+    RED, 
+    @JsonProperty("dark-blue")
+    DARK_BLUE, 
+}
+
+// Paint is a paint
+record Paint(
+    @JsonProperty("color") Color color, 
+    @JsonProperty("alpha") Float alpha, 
+    @JsonProperty("labels") Map<String, String> labels 
+) {
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to kotlin",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "kotlin",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+namespace * demo
+
+enum EEE {
+    A = 1;
+    B = 2;
+}
+
+struct SS {
+    1: optional bool a,
+    2: byte b,
+    3: string c,
+    4: binary d,
+    5: map<i32, string> e,
+    6: optional list<i32> f,
+    7: set<string> g,
+    8: list<map<string, i64>> h,
+}
+
+struct BBB {
+    1: EEE e,
+    2: map<string, BBB> mapab,
+    3: list<BBB> listb,
+}
+					`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`package demo
+
+import kotlinx.serialization.KSerializer
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.descriptors.PrimitiveKind
+import kotlinx.serialization.descriptors.PrimitiveSerialDescriptor
+import kotlinx.serialization.encoding.Decoder
+import kotlinx.serialization.encoding.Encoder
+
+@Serializable(with = Eee.Serializer::class)
+enum class Eee(val value: Int) {
+    A(1), 
+    B(2), 
+    ;
+
+    object Serializer : KSerializer<Eee> {
+        override val descriptor = PrimitiveSerialDescriptor("Eee", PrimitiveKind.INT)
+
+        override fun serialize(encoder: Encoder, value: Eee) = encoder.encodeInt(value.value)
+
+        override fun deserialize(decoder: Decoder): Eee {
+            val value = decoder.decodeInt()
+            return values().first { it.value == value }
+        }
+    }
+}
+
+@Serializable
+data class Ss(
+    @SerialName("a")
+    val a: Boolean? = null, 
+    @SerialName("b")
+    val b: Byte, 
+    @SerialName("c")
+    val c: String, 
+    @SerialName("d")
+    val d: ByteArray, 
+    @SerialName("e")
+    val e: Map<Int, String>, 
+    @SerialName("f")
+    val f: List<Int>? = null, 
+    @SerialName("g")
+    val g: Set<String>, 
+    @SerialName("h")
+    val h: List<Map<String, Long>>, 
+)
+
+@Serializable
+data class Bbb(
+    @SerialName("e")
+    val e: Eee, 
+    @SerialName("mapab")
+    val mapab: Map<String, Bbb>, 
+    @SerialName("listb")
+    val listb: List<Bbb>, 
+)
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to kt",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "kt",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"userName": "a", "in": 1, "items": [{"k": true}], "d": null}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.json.JsonElement
+
+@Serializable
+data class Items(
+    @SerialName("k")
+    val k: Boolean, 
+)
+
+@Serializable
+data class Root(
+    @SerialName("d")
+    val d: JsonElement, 
+    @SerialName("in")
+    val ` + "`in`" + `: Long, 
+    @SerialName("items")
+    val items: List<Items>, 
+    @SerialName("userName")
+    val userName: String, 
+)
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to java with non-identifier keys",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "java",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"a-b": 1, "ok": true}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import com.fasterxml.jackson.annotation.JsonProperty;
+
+record Root(
+    @JsonProperty("a-b") long ab, 
+    @JsonProperty("ok") boolean ok 
+) {
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to kotlin with non-identifier keys",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "kotlin",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"a-b": 1, "ok": true}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+
+@Serializable
+data class Root(
+    @SerialName("a-b")
+    val ab: Long, 
+    @SerialName("ok")
+    val ok: Boolean, 
+)
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to kotlin with string enum",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "kotlin",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Color string
+
+const (
+	ColorRed      Color = "red"
+	ColorDarkBlue Color = "dark-blue"
+)
+
+// Paint is a paint
+type Paint struct {
+	Color  Color
+	Alpha  *float32
+	Labels map[string]string
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`package main
+
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+
+@Serializable
+enum class Color {
+    @SerialName("red")
+    RED, 
+    @SerialName("dark-blue")
+    DARK_BLUE, 
+}
+
+// Paint is a paint
+@Serializable
+data class Paint(
+    @SerialName("color")
+    val color: Color, 
+    @SerialName("alpha")
+    val alpha: Float? = null, 
+    @SerialName("labels")
+    val labels: Map<String, String>, 
+)
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "proto to jsonschema",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "proto",
+						Dst: "jsonschema",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+
+// color
+enum Color {
+    RED = 0;
+    BLUE = 1;
+}
+
+// a message
+message A {
+    // the id
+    int64 id = 1;
+    optional string name = 2; // the name
+    repeated Color colors = 3;
+    map<string, B> bs = 4;
+    bytes data = 5;
+}
+
+message B {
+}
+					`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$defs": {
+    "Color": {
+      "type": "integer",
+      "description": "color",
+      "enum": [
+        0,
+        1
+      ]
+    },
+    "A": {
+      "type": "object",
+      "description": "a message",
+      "properties": {
+        "id": {
+          "description": "the id",
+          "type": "integer"
+        },
+        "name": {
+          "description": "the name",
+          "type": "string"
+        },
+        "colors": {
+          "type": "array",
+          "items": {
+            "$ref": "#/$defs/Color"
+          }
+        },
+        "bs": {
+          "type": "object",
+          "additionalProperties": {
+            "$ref": "#/$defs/B"
+          }
+        },
+        "data": {
+          "type": "string",
+          "contentEncoding": "base64"
+        }
+      },
+      "required": [
+        "id",
+        "colors",
+        "bs",
+        "data"
+      ]
+    },
+    "B": {
+      "type": "object",
+      "properties": {},
+      "required": []
+    }
+  }
+}
+`),
+			wantErr: false,
+		},
+		{
+			name: "jsonschema to go with string enum",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "jsonschema",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"$defs": {"Status": {"enum": ["in-progress", "done"]}}, "type":"object", "properties": {"status": {"$ref": "#/$defs/Status"}}}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`type Status string
+
+const (
+	Inprogress Status = "in-progress"
+	Done       Status = "done"
+)
+
+type Root struct {
+	Status Status ` + "`" + `json:"status,omitempty"` + "`" + `
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "jsonschema to go with recursive array def",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "jsonschema",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"$defs":{"L":{"type":"array","items":{"$ref":"#/$defs/L"}}},"type":"object","properties":{"l":{"$ref":"#/$defs/L"}}}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`type Root struct {
+	L []any ` + "`" + `json:"l,omitempty"` + "`" + `
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "jsonschema to go with recursive variant def",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "jsonschema",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"$defs":{"Node":{"oneOf":[{"type":"string"},{"type":"object","properties":{"children":{"type":"array","items":{"$ref":"#/$defs/Node"}}}}]}},"type":"object","properties":{"root":{"$ref":"#/$defs/Node"}}}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`type Node2 struct {
+	Children []*Node ` + "`" + `json:"children,omitempty"` + "`" + `
+}
+
+type Node struct {
+	Value1 *string
+	Node2  *Node2
+}
+
+type Root struct {
+	Root *Node ` + "`" + `json:"root,omitempty"` + "`" + `
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to jsonschema with non-identifier keys",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "jsonschema",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"a-b": 1, "say \"hi\"": "x"}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$ref": "#/$defs/Root",
+  "$defs": {
+    "Root": {
+      "type": "object",
+      "properties": {
+        "a-b": {
+          "type": "integer"
+        },
+        "say \"hi\"": {
+          "type": "string"
+        }
+      },
+      "required": [
+        "a-b",
+        "say \"hi\""
+      ]
+    }
+  }
+}
+`),
+			wantErr: false,
+		},
+		{
+			name: "json array to jsonschema",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "json",
+						Dst: "jsonschema",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`[{"a": 1}]`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "array",
+  "items": {
+    "$ref": "#/$defs/Root"
+  },
+  "$defs": {
+    "Root": {
+      "type": "object",
+      "properties": {
+        "a": {
+          "type": "integer"
+        }
+      },
+      "required": [
+        "a"
+      ]
+    }
+  }
+}
+`),
+			wantErr: false,
+		},
+		{
+			name: "csv to jsonschema",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "csv",
+						Dst: "jsonschema",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`name,age
+bob,1
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$ref": "#/$defs/Root",
+  "$defs": {
+    "Root": {
+      "type": "object",
+      "properties": {
+        "name": {
+          "type": "string"
+        },
+        "age": {
+          "type": "string"
+        }
+      },
+      "required": [
+        "name",
+        "age"
+      ]
+    }
+  }
+}
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to proto with detect format",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src:          "json",
+						Dst:          "proto",
+						DetectFormat: true,
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{
+    "id": "123e4567-e89b-12d3-a456-426614174000",
+    "created_at": "2024-01-02T15:04:05Z",
+    "avatar": "https://github.com/a.png",
+    "data": "aGVsbG8gd29ybGQgaGVsbG8=",
+    "name": "hello"
+}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+import "google/protobuf/timestamp.proto";
+
+message Root {
+    string avatar = 1; // url
+    google.protobuf.Timestamp created_at = 2; 
+    bytes data = 3; 
+    string id = 4; // uuid
+    string name = 5; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "json to go with detect format",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src:          "json",
+						Dst:          "go",
+						DetectFormat: true,
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`{"at": "2024-01-02T15:04:05Z", "data": "aGVsbG8gd29ybGQh", "seq": "2024010112345678"}`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import (
+	"time"
+)
+
+type Root struct {
+	At   time.Time ` + "`" + `json:"at,omitempty"` + "`" + `
+	Data []byte    ` + "`" + `json:"data,omitempty"` + "`" + `
+	Seq  string    ` + "`" + `json:"seq,omitempty"` + "`" + `
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "yaml to go with timestamp",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "yaml",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+a: 2001-12-14T21:59:43.10-05:00
+b: "2001-12-14T21:59:43.10-05:00"
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import (
+	"time"
+)
+
+type Root struct {
+	A time.Time ` + "`" + `yaml:"a"` + "`" + `
+	B string    ` + "`" + `yaml:"b"` + "`" + `
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "proto to proto with oneof",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "proto",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+
+message SampleMessage {
+  int32 id = 1;
+  // choice
+  oneof test_oneof {
+    string name = 4; // name
+    SubMessage sub_message = 9;
+  }
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+message SampleMessage {
+    int32 id = 1; 
+    // choice
+    oneof test_oneof {
+        string name = 4; // name
+        SubMessage sub_message = 9; 
+    }
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "proto to thrift with nested message",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "proto",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+
+message Outer {
+  message Inner {
+    string name = 1;
+  }
+  Inner inner = 1;
+  int32 id = 2;
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`struct Outer_Inner {
+    1: string name, 
+}
+
+struct Outer {
+    1: Outer_Inner inner, 
+    2: i32 id, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "proto to thrift with service",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "proto",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+import "google/protobuf/empty.proto";
+
+message Req {
+  string name = 1;
+}
+
+message Resp {
+  string msg = 1;
+}
+
+// Greeter greets
+service Greeter {
+  // SayHello say hello
+  rpc SayHello (Req) returns (Resp);
+  rpc Chat (stream Req) returns (stream Resp); // chat
+  rpc Ping (google.protobuf.Empty) returns (google.protobuf.Empty);
+  rpc Watch (Req) returns (stream Resp);
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`struct Req {
+    1: string name, 
+}
+
+struct Resp {
+    1: string msg, 
+}
+
+// Greeter greets
+service Greeter {
+    // SayHello say hello
+    Resp SayHello(1: Req req), 
+    Resp Chat(1: Req req) (streaming.mode="bidirectional"), // chat
+    void Ping(), 
+    Resp Watch(1: Req req) (streaming.mode="server"), 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "proto to go with service",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "proto",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+import "google/protobuf/empty.proto";
+
+message Req {
+  string name = 1;
+}
+
+message Resp {
+  string msg = 1;
+}
+
+// Greeter greets
+service Greeter {
+  // SayHello say hello
+  rpc SayHello (Req) returns (Resp);
+  rpc Chat (stream Req) returns (stream Resp); // chat
+  rpc Ping (google.protobuf.Empty) returns (google.protobuf.Empty);
+  rpc Watch (Req) returns (stream Resp);
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import (
+	"context"
+)
+
+type Req struct {
+	Name string
+}
+
+type Resp struct {
+	Msg string
+}
+
+// Greeter greets
+type Greeter interface {
+	// SayHello say hello
+	SayHello(ctx context.Context, req *Req) (*Resp, error)
+	Chat(ctx context.Context, req <-chan *Req) (<-chan *Resp, error) // chat
+	Ping(ctx context.Context) error
+	Watch(ctx context.Context, req *Req) (<-chan *Resp, error)
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to proto with service",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+struct Req {
+    1: string name,
+}
+
+struct Resp {
+    1: string msg,
+}
+
+service Greeter {
+    Resp SayHello(1: Req req),
+    Resp Chat(1: Req req) (streaming.mode="bidirectional"),
+    void Ping(),
+    string Get(1: i64 id, 2: string key),
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+import "google/protobuf/empty.proto";
+import "google/protobuf/wrappers.proto";
+
+message Req {
+    string name = 1; 
+}
+
+message Resp {
+    string msg = 1; 
+}
+
+message GreeterGetArgs {
+    int64 id = 1; 
+    string key = 2; 
+}
+
+service Greeter {
+    rpc SayHello (Req) returns (Resp); 
+    rpc Chat (stream Req) returns (stream Resp); 
+    rpc Ping (google.protobuf.Empty) returns (google.protobuf.Empty); 
+    rpc Get (GreeterGetArgs) returns (google.protobuf.StringValue); 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to proto with namespace",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+namespace go tenfyzhong.st2
+namespace java com.tenfyzhong.st2
+
+struct Req {
+    1: string name,
+    2: i64 created_at,
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+option go_package = "tenfyzhong/st2";
+
+message Req {
+    string name = 1; 
+    int64 created_at = 2; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to go with package override",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src:       "thrift",
+						Dst:       "go",
+						Package:   "model",
+						GoPackage: "github.com/tenfyzhong/model",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+namespace go tenfyzhong.st2
+namespace java com.tenfyzhong.st2
+
+struct Req {
+    1: string name,
+    2: i64 created_at,
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`package model
+
+type Req struct {
+	Name      string
+	CreatedAt int64
+}
+`),
+			wantErr: false,
+		},
+		{
+			name: "proto to go with well-known types",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "proto",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+package api;
+
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/wrappers.proto";
+
+message Event {
+  google.protobuf.Timestamp at = 1;
+  google.protobuf.StringValue note = 2;
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`package api
+
+import (
+	"time"
+)
+
+type Event struct {
+	At   time.Time
+	Note *string
+}
+`),
+			wantErr: false,
+		},
+		{
+			name: "proto to thrift with well-known types",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "proto",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+syntax = "proto3";
+package api;
+
+import "google/protobuf/timestamp.proto";
+import "google/protobuf/wrappers.proto";
+
+message Event {
+  google.protobuf.Timestamp at = 1;
+  google.protobuf.StringValue note = 2;
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`namespace * api
+
+struct Event {
+    1: i64 at, 
+    2: optional string note, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to go with typedef and constant",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+typedef i64 UserId
+typedef list<string> Tags
+typedef User Person
+
+enum Status {
+    OK = 0,
+    FAIL = 1,
+}
+
+const i32 MAX_SIZE = 10
+const string NAME = "st2"
+const double RATE = 0.5
+const Status DEFAULT_STATUS = Status.OK
+const list<i64> IDS = [1, 2]
+const map<string, i32> M = {"a": 1}
+const set<string> S = ["x"]
+const bool ENABLE = true
+
+struct User {
+    1: UserId id = 1,
+    2: string name = "anonymous",
+    3: Tags tags = ["a", "b"],
+    4: Status status = Status.FAIL,
+    5: optional i32 age = 18,
+    6: Person friend,
+    7: bool active = true,
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`type UserId int64
+
+type Tags []string
+
+type Person User
+
+type Status int
+
+const (
+	OK   Status = 0
+	FAIL Status = 1
+)
+
+type User struct {
+	Id     UserId
+	Name   string
+	Tags   Tags
+	Status Status
+	Age    *int32
+	Friend *Person
+	Active bool
+}
+
+func NewUser() *User {
+	return &User{
+		Id:     1,
+		Name:   "anonymous",
+		Tags:   Tags{"a", "b"},
+		Status: FAIL,
+		Active: true,
+	}
+}
+
+const (
+	MAX_SIZE       int32   = 10
+	NAME           string  = "st2"
+	RATE           float64 = 0.5
+	DEFAULT_STATUS Status  = OK
+	ENABLE         bool    = true
+)
+
+var (
+	IDS []int64          = []int64{1, 2}
+	M   map[string]int32 = map[string]int32{"a": 1}
+	S   map[string]bool  = map[string]bool{"x": true}
+)
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to thrift with typedef and constant",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+typedef i64 UserId
+typedef list<string> Tags
+typedef User Person
+
+enum Status {
+    OK = 0,
+    FAIL = 1,
+}
+
+const i32 MAX_SIZE = 10
+const string NAME = "st2"
+const double RATE = 0.5
+const Status DEFAULT_STATUS = Status.OK
+const list<i64> IDS = [1, 2]
+const map<string, i32> M = {"a": 1}
+const set<string> S = ["x"]
+const bool ENABLE = true
+
+struct User {
+    1: UserId id = 1,
+    2: string name = "anonymous",
+    3: Tags tags = ["a", "b"],
+    4: Status status = Status.FAIL,
+    5: optional i32 age = 18,
+    6: Person friend,
+    7: bool active = true,
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`typedef i64 UserId
+typedef list<string> Tags
+typedef User Person
+
+enum Status {
+    OK = 0; 
+    FAIL = 1; 
+}
+
+struct User {
+    1: UserId id = 1, 
+    2: string name = "anonymous", 
+    3: Tags tags = ["a", "b"], 
+    4: Status status = Status.FAIL, 
+    5: optional i32 age = 18, 
+    6: Person friend, 
+    7: bool active = true, 
+}
+
+const i32 MAX_SIZE = 10
+const string NAME = "st2"
+const double RATE = 0.5
+const Status DEFAULT_STATUS = Status.OK
+const list<i64> IDS = [1, 2]
+const map<string, i32> M = {"a": 1}
+const set<string> S = ["x"]
+const bool ENABLE = true
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to go with included enum default",
+			args: func(t *testing.T) args {
+				dir := t.TempDir()
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "shared.thrift"), []byte(`
+enum Color {
+    RED = 1,
+    BLUE = 2,
+}
+`), 0644))
+				a := args{
+					ctx: Context{
+						Src:         "thrift",
+						Dst:         "go",
+						ImportPaths: []string{dir},
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+include "shared.thrift"
+
+struct Item {
+    1: shared.Color color = shared.Color.RED,
+    2: list<shared.Color> colors = [shared.Color.BLUE],
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import (
+	"shared"
+)
+
+type Item struct {
+	Color  shared.Color
+	Colors []shared.Color
+}
+
+func NewItem() *Item {
+	return &Item{
+		Color:  shared.ColorRED,
+		Colors: []shared.Color{shared.ColorBLUE},
+	}
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to go with included go namespace",
+			args: func(t *testing.T) args {
+				dir := t.TempDir()
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "shared.thrift"), []byte(`
+namespace go example.base
+
+struct Base {
+    1: i64 id,
+}
+`), 0644))
+				a := args{
+					ctx: Context{
+						Src:         "thrift",
+						Dst:         "go",
+						ImportPaths: []string{dir},
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+include "shared.thrift"
+
+namespace go example.item
+
+struct Item {
+    1: shared.Base base,
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`package item
+
+import (
+	shared "example/base"
+)
+
+type Item struct {
+	Base *shared.Base
+}
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to proto with include",
+			args: func(t *testing.T) args {
+				dir := t.TempDir()
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "shared.thrift"), []byte(`
+struct Base {
+    1: i64 id,
+}
+`), 0644))
+				a := args{
+					ctx: Context{
+						Src:         "thrift",
+						Dst:         "proto",
+						ImportPaths: []string{dir},
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+include "shared.thrift"
+
+struct Item {
+    1: shared.Base base,
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+import "shared.proto";
+
+message Item {
+    shared.Base base = 1; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to go with annotation",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+// enum comment
+enum E {
+    // value comment
+    A = 1,
+    B = 2 (x="y"),
+} (e.anno="1")
+
+// struct comment
+struct S {
+    // field comment
+    1: string a (go.tag="json:\"a\" db:\"a\"", api.body="a"),
+    2: i32 b,
+} (s.anno="v")
+
+// typedef comment
+typedef i64 Id
+
+// const comment
+const i32 C = 1
+
+// service comment
+service Svc {
+    // func comment
+    void f(),
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import (
+	"context"
+)
+
+// typedef comment
+type Id int64
+
+// enum comment
+type E int
+
+const (
+	// value comment
+	A E = 1
+	B E = 2
+)
+
+// struct comment
+type S struct {
+	// field comment
+	A string ` + "`" + `json:"a" db:"a"` + "`" + `
+	B int32
+}
+
+const (
+	// const comment
+	C int32 = 1
+)
+
+// service comment
+type Svc interface {
+	// func comment
+	F(ctx context.Context) error
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to thrift with annotation",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+// enum comment
+enum E {
+    // value comment
+    A = 1,
+    B = 2 (x="y"),
+} (e.anno="1")
+
+// struct comment
+struct S {
+    // field comment
+    1: string a (go.tag="json:\"a\" db:\"a\"", api.body="a"),
+    2: i32 b,
+} (s.anno="v")
+
+// typedef comment
+typedef i64 Id
+
+// const comment
+const i32 C = 1
+
+// service comment
+service Svc {
+    // func comment
+    void f(),
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`// typedef comment
+typedef i64 Id
+
+// enum comment
+enum E {
+    // value comment
+    A = 1; 
+    B = 2 (x="y"); 
+} (e.anno="1")
+
+// struct comment
+struct S {
+    // field comment
+    1: string a (go.tag="json:\"a\" db:\"a\"", api.body="a"), 
+    2: i32 b, 
+} (s.anno="v")
+
+// const comment
+const i32 C = 1
+
+// service comment
+service Svc {
+    // func comment
+    void f(), 
+}
+
+`),
+			wantErr: false,
+		},
+
+		{
+			name: "thrift to go with exception",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "go",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+exception NotFound {
+    1: string message,
+    2: i32 code,
+}
+
+service Svc {
+    string get(1: i64 id) throws (1: NotFound nf),
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`import (
+	"context"
+	"fmt"
+)
+
+type NotFound struct {
+	Message string
+	Code    int32
+}
+
+func (e *NotFound) Error() string {
+	return fmt.Sprintf("NotFound(%+v)", *e)
+}
+
+type SvcGetArgs struct {
+	Id int64
+}
+
+type Svc interface {
+	Get(ctx context.Context, req *SvcGetArgs) (string, error)
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "thrift to thrift with exception",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "thrift",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+exception NotFound {
+    1: string message,
+    2: i32 code,
+}
+
+service Svc {
+    string get(1: i64 id) throws (1: NotFound nf),
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`exception NotFound {
+    1: string message, 
+    2: i32 code, 
+}
+
+struct SvcGetArgs {
+    1: i64 id, 
+}
+
+service Svc {
+    string get(1: SvcGetArgs req) throws (1: NotFound nf), 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to proto with iota and string enum",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Kind int
+
+const (
+	KindA Kind = iota
+	KindB
+)
+
+type Color string
+
+const (
+	Red  Color = "red"
+	Blue Color = "blue"
+)
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+package main;
+
+enum Kind {
+    KindA = 0; 
+    KindB = 1; 
+}
+
+enum Color {
+    Red = 0; 
+    Blue = 1; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to proto with iota enum skipping zero",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Kind int
+
+const (
+	_ Kind = iota
+	KindA
+	KindB
+)
+
+type T struct {
+	Kind Kind
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+package main;
+
+enum Kind {
+    KIND_UNSPECIFIED = 0; 
+    KindA = 1; 
+    KindB = 2; 
+}
+
+message T {
+    Kind kind = 1; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to proto with untagged field before numbered field",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+	ID   int64  ` + "`protobuf:\"varint,1,opt,name=id,proto3\"`" + `
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+package main;
+
+message User {
+    string name = 2; 
+    int64 id = 1; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to jsonschema with string enum",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "jsonschema",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Kind int
+
+const (
+	KindA Kind = iota
+	KindB
+)
+
+type Color string
+
+const (
+	Red  Color = "red"
+	Blue Color = "blue"
+)
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$defs": {
+    "Kind": {
+      "type": "integer",
+      "enum": [
+        0,
+        1
+      ]
+    },
+    "Color": {
+      "type": "string",
+      "enum": [
+        "red",
+        "blue"
+      ]
+    }
+  }
+}
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to thrift with typedef",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src:     "go",
+						Dst:     "thrift",
+						Typedef: true,
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+// UserID is the id of user
+type UserID int64
+type Meta = map[string]string
+
+type User struct {
+	ID   UserID
+	Meta Meta
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`namespace * main
+
+// UserID is the id of user
+typedef i64 UserID
+typedef map<string, string> Meta
+
+struct User {
+    1: UserID id, 
+    2: Meta meta, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to proto with typedef",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src:     "go",
+						Dst:     "proto",
+						Typedef: true,
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+// UserID is the id of user
+type UserID int64
+type Meta = map[string]string
+
+type User struct {
+	ID   UserID
+	Meta Meta
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+package main;
+
+// UserID is the id of user
+// typedef int64 UserID
+// typedef map<string, string> Meta
+
+message User {
+    int64 id = 1; 
+    map<string, string> meta = 2; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to proto with generics",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+package main
+
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+type Counts Pair[string, []int32]
+
+type Resp struct {
+	Pair *Pair[string, int32]
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+package main;
+
+message Counts {
+    string key = 1; 
+    repeated int32 value = 2; 
+}
+
+message Resp {
+    PairStringInt32 pair = 1; 
+}
+
+message PairStringInt32 {
+    string key = 1; 
+    int32 value = 2; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to proto with protoc-gen-go code",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package user
+
+import (
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type User_Kind int32
+
+const (
+	User_ADMIN User_Kind = 0
+	User_GUEST User_Kind = 1
+)
+
+// Enum value maps for User_Kind.
+var (
+	User_Kind_name = map[int32]string{
+		0: "ADMIN",
+		1: "GUEST",
+	}
+	User_Kind_value = map[string]int32{
+		"ADMIN": 0,
+		"GUEST": 1,
+	}
+)
+
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64                  ` + "`" + `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` + "`" + `
+	Nickname *string                ` + "`" + `protobuf:"bytes,2,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"` + "`" + `
+	Kind     User_Kind              ` + "`" + `protobuf:"varint,4,opt,name=kind,proto3,enum=user.User_Kind" json:"kind,omitempty"` + "`" + `
+	Created  *timestamppb.Timestamp ` + "`" + `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"` + "`" + `
+	// Types that are assignable to Contact:
+	//
+	//	*User_Email
+	//	*User_Phone
+	Contact          isUser_Contact ` + "`protobuf_oneof:\"contact\"`" + `
+	XXX_unrecognized []byte         ` + "`" + `json:"-"` + "`" + `
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Email struct {
+	Email string ` + "`" + `protobuf:"bytes,7,opt,name=email,proto3,oneof"` + "`" + `
+}
+
+type User_Phone struct {
+	Phone string ` + "`" + `protobuf:"bytes,8,opt,name=phone,proto3,oneof"` + "`" + `
+}
+
+func (*User_Email) isUser_Contact() {}
+
+func (*User_Phone) isUser_Contact() {}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+import "google/protobuf/timestamp.proto";
+
+package user;
+
+enum User_Kind {
+    ADMIN = 0; 
+    GUEST = 1; 
+}
+
+message User {
+    int64 id = 1; 
+    optional string nickname = 2; 
+    User_Kind kind = 4; 
+    google.protobuf.Timestamp created = 6; 
+    oneof contact {
+        string email = 7; 
+        string phone = 8; 
+    }
+}
+
+`),
+		},
+		{
+			name: "go to proto with protoc-gen-go proto3 optional",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package user
+
+type User struct {
+	Id   int64   ` + "`protobuf:\"varint,1,opt,name=id,proto3\" json:\"id,omitempty\"`" + `
+	Name *string ` + "`protobuf:\"bytes,2,opt,name=name,proto3,oneof\" json:\"name,omitempty\"`" + `
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+package user;
+
+message User {
+    int64 id = 1; 
+    optional string name = 2; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to proto with protoc-gen-go bytes",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "proto",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package user
+
+type User struct {
+	Id     int64  ` + "`protobuf:\"varint,1,opt,name=id,proto3\" json:\"id,omitempty\"`" + `
+	Avatar []byte ` + "`protobuf:\"bytes,2,opt,name=avatar,proto3\" json:\"avatar,omitempty\"`" + `
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`syntax = "proto3";
+
+package user;
+
+message User {
+    int64 id = 1; 
+    bytes avatar = 2; 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to thrift with protoc-gen-go bytes",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package user
+
+type User struct {
+	Id     int64   ` + "`protobuf:\"varint,1,opt,name=id,proto3\" json:\"id,omitempty\"`" + `
+	Avatar []uint8 ` + "`protobuf:\"bytes,2,opt,name=avatar,proto3\" json:\"avatar,omitempty\"`" + `
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`namespace * user
+
+struct User {
+    1: i64 id, 
+    2: binary avatar, 
+}
+
+`),
+			wantErr: false,
+		},
+		{
+			name: "go to thrift with protoc-gen-go code",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+// Code generated by protoc-gen-go. DO NOT EDIT.
+
+package user
+
+import (
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type User_Kind int32
+
+const (
+	User_ADMIN User_Kind = 0
+	User_GUEST User_Kind = 1
+)
+
+// Enum value maps for User_Kind.
+var (
+	User_Kind_name = map[int32]string{
+		0: "ADMIN",
+		1: "GUEST",
+	}
+	User_Kind_value = map[string]int32{
+		"ADMIN": 0,
+		"GUEST": 1,
+	}
+)
+
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64                  ` + "`" + `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"` + "`" + `
+	Nickname *string                ` + "`" + `protobuf:"bytes,2,opt,name=nickname,proto3,oneof" json:"nickname,omitempty"` + "`" + `
+	Kind     User_Kind              ` + "`" + `protobuf:"varint,4,opt,name=kind,proto3,enum=user.User_Kind" json:"kind,omitempty"` + "`" + `
+	Created  *timestamppb.Timestamp ` + "`" + `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"` + "`" + `
+	// Types that are assignable to Contact:
+	//
+	//	*User_Email
+	//	*User_Phone
+	Contact          isUser_Contact ` + "`protobuf_oneof:\"contact\"`" + `
+	XXX_unrecognized []byte         ` + "`" + `json:"-"` + "`" + `
+}
+
+func (x *User) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Email struct {
+	Email string ` + "`" + `protobuf:"bytes,7,opt,name=email,proto3,oneof"` + "`" + `
+}
+
+type User_Phone struct {
+	Phone string ` + "`" + `protobuf:"bytes,8,opt,name=phone,proto3,oneof"` + "`" + `
+}
+
+func (*User_Email) isUser_Contact() {}
+
+func (*User_Phone) isUser_Contact() {}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`namespace * user
+
+enum User_Kind {
+    ADMIN = 0; 
+    GUEST = 1; 
+}
+
+struct User {
+    1: i64 id, 
+    2: optional string nickname, 
+    4: User_Kind kind, 
+    6: i64 created, 
+    7: User_Contact contact, 
+}
+
+union User_Contact {
+    7: string email, 
+    8: string phone, 
+}
+
+`),
+		},
+		{
+			name: "go to thrift with kitex code",
+			args: func(t *testing.T) args {
+				a := args{
+					ctx: Context{
+						Src: "go",
+						Dst: "thrift",
+					},
+					buffer: bytes.NewBuffer(nil),
+					reader: bytes.NewReader([]byte(`
+// Code generated by thriftgo (0.3.13). DO NOT EDIT.
+
+package user
+
+type Status int64
+
+const (
+	Status_UNKNOWN Status = 0
+	Status_OK      Status = 1
+)
+
+type User struct {
+	Id     int64   ` + "`" + `thrift:"id,1,required" frugal:"1,required,i64" json:"id"` + "`" + `
+	Name   *string ` + "`" + `thrift:"name,2,optional" frugal:"2,optional,string" json:"name,omitempty"` + "`" + `
+	Status Status  ` + "`" + `thrift:"status,3" frugal:"3,default,Status" json:"status"` + "`" + `
+	Note   string  ` + "`" + `thrift:"note,4" frugal:"4,default,string" json:"note,omitempty"` + "`" + `
+}
+
+func NewUser() *User {
+	return &User{}
+}
+`)),
+				}
+				a.writer = a.buffer
+				return a
+			},
+			wantData: []byte(`namespace * user
+
+enum Status {
+    UNKNOWN = 0; 
+    OK = 1; 
+}
+
+struct User {
+    1: i64 id, 
+    2: optional string name, 
+    3: Status status, 
+    4: string note, 
+}
+
+`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,7 +1,13 @@
 package st2
 
 import (
+	"encoding/json"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+
+	"github.com/iancoleman/strcase"
 )
 
 // Comment record a [Member] or [Struct] comments
@@ -11,6 +17,42 @@ type Comment struct {
 	BeginningComments []string
 }
 
+// Text get the comment content without the comment marks
+func (c Comment) Text() string {
+	lines := make([]string, 0, len(c.BeginningComments)+1)
+	for _, comment := range c.BeginningComments {
+		lines = append(lines, commentText(comment))
+	}
+	if c.InlineComment != "" {
+		lines = append(lines, commentText(c.InlineComment))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// JsonDescription get the comment content as a quoted json string,
+// it returns empty string if there is no comment
+func (c Comment) JsonDescription() string {
+	text := c.Text()
+	if text == "" {
+		return ""
+	}
+	data, _ := json.Marshal(text)
+	return string(data)
+}
+
+func commentText(comment string) string {
+	comment = strings.TrimSpace(comment)
+	switch {
+	case strings.HasPrefix(comment, "//"):
+		comment = strings.TrimPrefix(comment, "//")
+	case strings.HasPrefix(comment, "#"):
+		comment = strings.TrimPrefix(comment, "#")
+	case strings.HasPrefix(comment, "/*"):
+		comment = strings.TrimSuffix(strings.TrimPrefix(comment, "/*"), "*/")
+	}
+	return strings.TrimSpace(comment)
+}
+
 // Member is fields of [Struct]
 type Member struct {
 	Field string
@@ -19,6 +61,91 @@ type Member struct {
 	Optional bool
 	Comment  Comment
 	GoTag    []string
+	// Oneof is the union struct of a protobuf oneof member, its members are
+	// inlined as a oneof block in proto
+	Oneof *Struct
+	// Default is the default value of the field, such as thrift
+	// `1: i32 a = 1`
+	Default *Value
+	// Annotations is the annotations of the field, such as thrift
+	// `(go.tag="json:\"a\"")`
+	Annotations Annotations
+	// Value is the value of an enum member which is not the Index, such as
+	// golang string enum `Red Color = "red"`
+	Value *Value
+	// Name is the original name of the field in the payload, such as the
+	// json key `a-b` of the field `ab`. It is empty if it is the same as
+	// the Field.
+	Name string
+	// Presence reports whether the optional-ness is recovered from the tag
+	// of the generated golang code, such as the protoc-gen-go
+	// `proto3,oneof`, the field tracks its presence explicitly
+	Presence bool
+}
+
+// SourceName get the original name of the field in the payload
+func (m Member) SourceName() string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return m.Field
+}
+
+var tsIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// TypeScriptField get the property name of the typescript interface, it is
+// the original name, quoted if it is not a valid identifier
+func (m Member) TypeScriptField() string {
+	name := m.SourceName()
+	if tsIdentifierRegexp.MatchString(name) {
+		return name
+	}
+	data, _ := json.Marshal(name)
+	return string(data)
+}
+
+// EnumValue get the golang literal of the enum member value, it is also
+// a valid typescript literal
+func (m Member) EnumValue() string {
+	if m.Value == nil {
+		return strconv.Itoa(m.Index)
+	}
+	return m.Value.Go(m.Type)
+}
+
+// JsonEnumValue get the json literal of the enum member value
+func (m Member) JsonEnumValue() string {
+	if m.Value == nil || m.Value.Kind != ValueString {
+		return m.EnumValue()
+	}
+	data, _ := json.Marshal(m.Value.Text)
+	return string(data)
+}
+
+// ProtoOptional reports whether the member is declared with the `optional`
+// label, it is set for the scalar field which tracks its presence only, see
+// [Member.Presence]
+func (m Member) ProtoOptional() bool {
+	if !m.Optional || !m.Presence || m.Oneof != nil {
+		return false
+	}
+	switch t := underlyingType(m.Type).(type) {
+	case *TimestampType, *DurationType:
+		// they are the well-known messages
+		return false
+	case *BinaryType, *EnumType:
+		return true
+	default:
+		return t.IsBasicType()
+	}
+}
+
+// GoDefault get the golang literal of the default value
+func (m Member) GoDefault() string {
+	if m.Default == nil {
+		return ""
+	}
+	return m.Default.Go(m.Type)
 }
 
 // FieldCamel get a camel type field name
@@ -35,12 +162,162 @@ func (m Member) Go() string {
 	return name
 }
 
+// JsonSchema get the json schema of the member, with the comment as
+// description
+func (m Member) JsonSchema() string {
+	schema := m.Type.JsonSchema()
+	description := m.Comment.JsonDescription()
+	if description == "" {
+		return schema
+	}
+	if schema == "{}" {
+		return `{"description": ` + description + `}`
+	}
+	return `{"description": ` + description + `, ` + strings.TrimPrefix(schema, "{")
+}
+
+// Rust get the rust field type string, the optional member is wrapped in
+// `Option`
+func (m Member) Rust() string {
+	if m.Optional {
+		return StrOption + "<" + m.Type.Rust() + ">"
+	}
+	return m.Type.Rust()
+}
+
+// RustField get the snake case field name of rust, the keyword is escaped
+// as a raw identifier
+func (m Member) RustField() string {
+	field := strings.ToLower(normalizeToken(snake(m.Field), "_"))
+	switch {
+	case rustReservedKeywords[field]:
+		// self, super, crate and Self can not be raw identifiers
+		return field + "_"
+	case rustKeywords[field]:
+		return "r#" + field
+	}
+	return field
+}
+
+// RustVariant get the upper camel case variant name of the rust enum, the
+// prefix of the enum name is trimmed, such as `Red` of golang `ColorRed`
+func (m Member) RustVariant() string {
+	return m.enumVariant()
+}
+
+func (m Member) enumVariant() string {
+	variant := upperCamelName(m.Field)
+	if t, ok := m.Type.(*EnumType); ok {
+		trimmed := strings.TrimPrefix(variant, upperCamelName(t.Name))
+		if trimmed != "" && unicode.IsUpper(rune(trimmed[0])) {
+			return trimmed
+		}
+	}
+	return variant
+}
+
+// RustSerde get the serde attribute of the rust field or string enum
+// variant, it renames the field to the original name, skips the absent
+// optional field, and flattens the protobuf oneof whose fields are in the
+// parent message. It is empty if there is nothing to declare.
+func (m Member) RustSerde() string {
+	attrs := make([]string, 0, 2)
+	if m.Value != nil && m.Value.Kind == ValueString {
+		// the variant of the string enum is renamed to the value
+		if m.RustVariant() != m.Value.Text {
+			attrs = append(attrs, "rename = "+m.JsonEnumValue())
+		}
+	} else {
+		if strings.TrimPrefix(m.RustField(), "r#") != m.SourceName() {
+			attrs = append(attrs, "rename = "+m.JsonField())
+		}
+		if m.Optional {
+			attrs = append(attrs, `skip_serializing_if = "Option::is_none"`)
+		}
+		if m.Oneof != nil {
+			attrs = append(attrs, "flatten")
+		}
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return "#[serde(" + strings.Join(attrs, ", ") + ")]"
+}
+
+// JsonField get the string literal of the original field name, it is the
+// property name in the json payloads
+func (m Member) JsonField() string {
+	data, _ := json.Marshal(m.SourceName())
+	return string(data)
+}
+
+// Java get the java field type string, the member which is not optional
+// uses the primitive type
+func (m Member) Java() string {
+	name := m.Type.Java()
+	if primitive, ok := javaPrimitives[name]; ok && !m.Optional {
+		return primitive
+	}
+	return name
+}
+
+// JavaField get the lower camel case field name of java, the keyword is
+// suffixed by underscore
+func (m Member) JavaField() string {
+	field := m.lowerCamelField()
+	if javaKeywords[field] {
+		return field + "_"
+	}
+	return field
+}
+
+// Kotlin get the kotlin field type string, the optional member is nullable
+func (m Member) Kotlin() string {
+	if m.Optional {
+		return m.Type.Kotlin() + "?"
+	}
+	return m.Type.Kotlin()
+}
+
+// KotlinField get the lower camel case field name of kotlin, the keyword is
+// escaped by backticks
+func (m Member) KotlinField() string {
+	field := m.lowerCamelField()
+	if kotlinKeywords[field] {
+		return "`" + field + "`"
+	}
+	return field
+}
+
+// KotlinSerialName get the string literal of the serial name, it is the
+// value of the string enum member, or the original field name. The `$` is
+// escaped as kotlin string templates.
+func (m Member) KotlinSerialName() string {
+	name := m.JsonField()
+	if m.Value != nil && m.Value.Kind == ValueString {
+		name = m.JsonEnumValue()
+	}
+	return strings.ReplaceAll(name, "$", `\$`)
+}
+
+// EnumConstant get the upper snake case constant name of the java and
+// kotlin enum, the prefix of the enum name is trimmed as [Member.RustVariant]
+func (m Member) EnumConstant() string {
+	return strings.ToUpper(snake(m.enumVariant()))
+}
+
+func (m Member) lowerCamelField() string {
+	return strcase.ToLowerCamel(normalizeToken(snake(m.Field), "_"))
+}
+
 // GoTagString get the go field tag string
 func (m Member) GoTagString() string {
-	if len(m.GoTag) == 0 {
+	// the thrift `go.tag` annotation is the golang field tag
+	tags := append(append([]string{}, m.GoTag...), m.Annotations.Get(ThriftGoTag)...)
+	if len(tags) == 0 {
 		return ""
 	}
-	return "`" + strings.Join(m.GoTag, " ") + "`"
+	return "`" + strings.Join(tags, " ") + "`"
 }
 
 // Struct is a parsed result which contains the source struct data
@@ -48,4 +325,106 @@ type Struct struct {
 	Type    Type
 	Members []*Member
 	Comment Comment
+	// Oneof reports whether the struct is a union from a protobuf oneof,
+	// it is inlined in the parent message in proto
+	Oneof bool
+	// Annotations is the annotations of the struct, such as thrift
+	// `(api.service="user")`
+	Annotations Annotations
+}
+
+// IsEnum reports whether the struct is an enum
+func (s Struct) IsEnum() bool {
+	_, ok := s.Type.(*EnumType)
+	return ok
+}
+
+// StringEnum reports whether the struct is an enum with string values,
+// such as golang `type Color string`
+func (s Struct) StringEnum() bool {
+	if !s.IsEnum() || len(s.Members) == 0 {
+		return false
+	}
+	for _, member := range s.Members {
+		if member.Value == nil || member.Value.Kind != ValueString {
+			return false
+		}
+	}
+	return true
+}
+
+// RequiredFields get the string literals of the original field names of
+// the members which are not optional, a union has no required field
+func (s Struct) RequiredFields() []string {
+	if t, ok := s.Type.(*StructLikeType); ok && t.Source == SLSUnion {
+		return nil
+	}
+	fields := make([]string, 0, len(s.Members))
+	for _, member := range s.Members {
+		if !member.Optional {
+			fields = append(fields, member.JsonField())
+		}
+	}
+	return fields
+}
+
+// RustDerive get the derive attribute of the rust struct or enum, the
+// integer enum is serialized as the number by serde_repr
+func (s Struct) RustDerive() string {
+	if _, ok := s.Type.(*EnumType); !ok {
+		return "#[derive(Debug, Clone, Serialize, Deserialize)]"
+	}
+	if s.StringEnum() {
+		return "#[derive(Debug, Clone, Copy, PartialEq, Eq, Serialize, Deserialize)]"
+	}
+	return "#[derive(Debug, Clone, Copy, PartialEq, Eq, Serialize_repr, Deserialize_repr)]"
+}
+
+// LastMemberIndex get the index of the last member, the components of the
+// java record are separated by comma
+func (s Struct) LastMemberIndex() int {
+	return len(s.Members) - 1
+}
+
+// ProtoMembers get the members of the protobuf enum, the `UNSPECIFIED` member
+// is added to the integer enum which has no zero value, such as the thrift
+// enum starting at 1. The first value of a proto3 enum must be zero.
+func (s Struct) ProtoMembers() []*Member {
+	t, ok := s.Type.(*EnumType)
+	if !ok || s.StringEnum() {
+		return s.Members
+	}
+	for _, member := range s.Members {
+		if member.Index == 0 {
+			return s.Members
+		}
+	}
+	zero := &Member{
+		Field: strings.ToUpper(snake(t.Name)) + "_UNSPECIFIED",
+		Type:  s.Type,
+	}
+	return append([]*Member{zero}, s.Members...)
+}
+
+// GoDefaults get the members which have default values can be set in the
+// golang constructor, the pointer of basic type is skipped as the literal is
+// not addressable
+func (s Struct) GoDefaults() []*Member {
+	res := make([]*Member, 0)
+	for _, m := range s.Members {
+		if m.Default == nil || m.Optional && m.Type.IsBasicType() || m.GoDefault() == "" {
+			continue
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+func (s Struct) usesGoTime() bool {
+	for _, m := range s.Members {
+		if strings.Contains(m.Go(), StrTime) || strings.Contains(m.Go(), StrDuration) {
+			return true
+		}
+	}
+	return false
 }
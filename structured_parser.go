@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"time"
 )
 
 type UnmarshalFunc func(data []byte, v any) error
@@ -14,6 +15,12 @@ type UnmarshalTagFormat interface {
 	TagFormat() string
 }
 
+// UnmarshalSamples is an interface to unmarshal a source which contains
+// multiple documents, such as ndjson or multi-document yaml
+type UnmarshalSamples interface {
+	UnmarshalSamples(data []byte) ([]any, error)
+}
+
 // StructuredParser is a Parser to parse structured data source
 type StructuredParser struct {
 	ctx Context
@@ -37,19 +44,31 @@ func NewStructuredParser(ctx Context, unmarshalTagFormat UnmarshalTagFormat) *St
 
 // Parse method parse structured data source
 func (p *StructuredParser) Parse(reader io.Reader) ([]*Struct, error) {
-	data, err := io.ReadAll(reader)
+	structs, _, err := p.parse(reader)
+	return structs, err
+}
+
+// ParseFile method parse structured data source to [File], the root is the
+// type of the whole document
+func (p *StructuredParser) ParseFile(reader io.Reader) (*File, error) {
+	structs, root, err := p.parse(reader)
 	if err != nil {
 		return nil, err
 	}
+	return &File{
+		Root:    root,
+		Structs: structs,
+	}, nil
+}
 
-	if len(data) == 0 {
-		return nil, nil
+func (p *StructuredParser) parse(reader io.Reader) ([]*Struct, Type, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	var v any
-	err = p.unmarshalTagFormat.Unmarshal(data, &v)
-	if err != nil {
-		return nil, err
+	if len(data) == 0 {
+		return nil, nil, nil
 	}
 
 	rootName := p.ctx.Root
@@ -57,15 +76,65 @@ func (p *StructuredParser) Parse(reader io.Reader) ([]*Struct, error) {
 		rootName = RootDefault
 	}
 
-	root := p.parseNode(rootName, v)
-	p.parseStructs(root)
+	var root *rawNode
+	if p.ctx.Merge {
+		samples, err := p.unmarshalSamples(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, sample := range samples {
+			root = mergeNode(root, p.parseNode(rootName, sample))
+		}
+	} else {
+		var v any
+		err = p.unmarshalTagFormat.Unmarshal(data, &v)
+		if err != nil {
+			return nil, nil, err
+		}
+		root = p.parseNode(rootName, v)
+	}
+
+	var rootType Type
+	if member := p.parseStructs(root); member != nil {
+		rootType = member.Type
+	}
 
 	structs := p.structs
 	p.fingerMap = make(map[string]*Struct)
 	p.nameMap = make(map[string]bool)
 	p.structs = p.structs[0:0]
 
-	return structs, nil
+	return structs, rootType, nil
+}
+
+// unmarshalSamples unmarshal all the documents in the data,
+// the elements of a top level array are treated as samples too
+func (p *StructuredParser) unmarshalSamples(data []byte) ([]any, error) {
+	var docs []any
+	if u, ok := p.unmarshalTagFormat.(UnmarshalSamples); ok {
+		var err error
+		docs, err = u.UnmarshalSamples(data)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var v any
+		err := p.unmarshalTagFormat.Unmarshal(data, &v)
+		if err != nil {
+			return nil, err
+		}
+		docs = []any{v}
+	}
+
+	samples := make([]any, 0, len(docs))
+	for _, doc := range docs {
+		if arr, ok := doc.([]any); ok {
+			samples = append(samples, arr...)
+		} else {
+			samples = append(samples, doc)
+		}
+	}
+	return samples, nil
 }
 
 func (p *StructuredParser) genUniqName(seed string) string {
@@ -91,8 +160,12 @@ func (p *StructuredParser) parseStructs(root *rawNode) *Member {
 	}
 
 	member := &Member{
-		Field: normalizeToken(root.Field, "A"),
-		GoTag: []string{fmt.Sprintf(p.unmarshalTagFormat.TagFormat(), root.Field)},
+		Field:    normalizeToken(root.Field, "A"),
+		Optional: root.Optional,
+		GoTag:    []string{fmt.Sprintf(p.unmarshalTagFormat.TagFormat(), root.Field)},
+	}
+	if member.Field != root.Field {
+		member.Name = root.Field
 	}
 
 	switch root.Type {
@@ -108,8 +181,13 @@ func (p *StructuredParser) parseStructs(root *rawNode) *Member {
 		Uint8Val,
 		Uint16Val,
 		Uint32Val,
-		Uint64Val:
+		Uint64Val,
+		TimestampVal,
+		BinaryVal:
 		member.Type = root.Type
+		if root.Format == FormatUUID || root.Format == FormatURL {
+			member.Comment.InlineComment = "// " + root.Format
+		}
 	case ArrayVal:
 		if len(root.Children) == 0 {
 			// ignore the current member if the array is empty
@@ -130,7 +208,6 @@ func (p *StructuredParser) parseStructs(root *rawNode) *Member {
 	case StructLikeVal:
 		finger := root.Fingerprint()
 		if st, ok := p.fingerMap[finger]; ok {
-			member.Field = root.Field
 			t, ok := st.Type.(*StructLikeType)
 			if !ok {
 				return nil
@@ -183,6 +260,7 @@ func (p *StructuredParser) parseNode(tag string, v any) *rawNode {
 	}
 	if v == nil {
 		node.Type = AnyVal
+		node.Null = true
 	}
 
 	switch c := v.(type) {
@@ -221,6 +299,18 @@ func (p *StructuredParser) parseNode(tag string, v any) *rawNode {
 		node.Type = Uint64Val
 	case string:
 		node.Type = StringVal
+		if p.ctx.DetectFormat {
+			node.Format = detectFormat(c)
+			switch node.Format {
+			case FormatDateTime:
+				node.Type = TimestampVal
+			case FormatBase64:
+				node.Type = BinaryVal
+			}
+		}
+	case time.Time:
+		// yaml and toml decode the date time value to time.Time
+		node.Type = TimestampVal
 	case map[string]any:
 		node.Type = StructLikeVal
 		node.Children = []*rawNode{}
@@ -231,12 +321,29 @@ func (p *StructuredParser) parseNode(tag string, v any) *rawNode {
 		sort.Sort(NodeList(node.Children))
 	case []any:
 		node.Type = ArrayVal
-		if len(c) > 0 {
-			child := p.parseNode("", c[0])
+		if len(c) > 0 && p.ctx.Merge {
+			// merge all the elements to get the shape of the element
+			var child *rawNode
+			for _, item := range c {
+				child = mergeElement(child, p.parseNode("", item))
+			}
+			node.Children = append(node.Children, child)
+		} else if len(c) > 0 {
+			// use the first non-null element as the shape of the element
+			item := c[0]
+			for _, v := range c {
+				if v != nil {
+					item = v
+					break
+				}
+			}
+			child := p.parseNode("", item)
 			node.Children = append(node.Children, child)
 		} else {
+			// the element of an empty array is unknown as null
 			child := &rawNode{
 				Type: AnyVal,
+				Null: true,
 			}
 			node.Children = append(node.Children, child)
 		}
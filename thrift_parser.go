@@ -3,6 +3,10 @@ package st2
 import (
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/cloudwego/thriftgo/parser"
 )
@@ -10,6 +14,17 @@ import (
 // ThriftParser is a Parser to parse thrift source
 type ThriftParser struct {
 	ctx Context
+
+	// typedefs is the typedefs of the file being parsed, key is the alias
+	typedefs map[string]*parser.Typedef
+	// enums is the enum names of the file being parsed
+	enums map[string]bool
+	// includes is the types declared in the included files, key is the
+	// qualified name, such as `shared.Base`
+	includes map[string]Type
+	// goPackages is the golang import paths of the included files, key is the
+	// include name, such as `shared`
+	goPackages map[string]string
 }
 
 // NewThriftParser create [ThriftParser]
@@ -21,13 +36,22 @@ func NewThriftParser(ctx Context) *ThriftParser {
 
 // Parse method parse thrift source
 func (p ThriftParser) Parse(reader io.Reader) ([]*Struct, error) {
+	file, err := p.ParseFile(reader)
+	if err != nil || file == nil {
+		return nil, err
+	}
+	return file.Structs, nil
+}
+
+// ParseFile method parse thrift source to [File], it contains the services
+func (p ThriftParser) ParseFile(reader io.Reader) (*File, error) {
 	data, err := io.ReadAll(reader)
 	if err != nil {
 		return nil, errors.New("read data failed")
 	}
 
 	if len(data) == 0 {
-		return nil, nil
+		return &File{}, nil
 	}
 
 	thrift, err := parser.ParseString("", string(data))
@@ -39,6 +63,47 @@ func (p ThriftParser) Parse(reader io.Reader) ([]*Struct, error) {
 		return nil, errors.New("Parse thrift failed")
 	}
 
+	p.includes = make(map[string]Type)
+	p.goPackages = make(map[string]string)
+	if err := p.parseIncludes(thrift, "", make(map[string]bool)); err != nil {
+		return nil, err
+	}
+	p.collectTypes(thrift)
+
+	file := &File{
+		IncludeGoPackages: p.goPackages,
+	}
+	for _, include := range thrift.Includes {
+		file.Includes = append(file.Includes, include.Path)
+	}
+	for _, t := range thrift.Typedefs {
+		file.Typedefs = append(file.Typedefs, &Typedef{
+			Name:    t.Alias,
+			Type:    p.type2Type(t.Type),
+			Comment: p.comment2Comment(t.ReservedComments),
+		})
+	}
+	for _, c := range thrift.Constants {
+		file.Constants = append(file.Constants, &Constant{
+			Name:    c.Name,
+			Type:    p.type2Type(c.Type),
+			Value:   p.constValue2Value(c.Value),
+			Comment: p.comment2Comment(c.ReservedComments),
+		})
+	}
+	for _, ns := range thrift.Namespaces {
+		switch ns.Language {
+		case "*":
+			file.Package = ns.Name
+		case LangGo:
+			file.GoPackage = strings.ReplaceAll(ns.Name, ".", "/")
+		default:
+			file.Namespaces = append(file.Namespaces, &Namespace{
+				Language: ns.Language,
+				Name:     ns.Name,
+			})
+		}
+	}
 	res := make([]*Struct, 0)
 
 	for _, e := range thrift.Enums {
@@ -53,7 +118,178 @@ func (p ThriftParser) Parse(reader io.Reader) ([]*Struct, error) {
 		res = append(res, p.structLike2struct(u, SLSUnion))
 	}
 
-	return res, nil
+	for _, e := range thrift.Exceptions {
+		res = append(res, p.structLike2struct(e, SLSException))
+	}
+
+	for _, s := range thrift.Services {
+		service, args := p.service2Service(s)
+		res = append(res, args...)
+		file.Services = append(file.Services, service)
+	}
+
+	file.Structs = res
+	return file, nil
+}
+
+// service2Service convert thrift service to [Service], the second result is
+// the structs synthesized from the arguments of the functions
+func (p ThriftParser) service2Service(s *parser.Service) (*Service, []*Struct) {
+	service := &Service{
+		Name:    s.Name,
+		Comment: p.comment2Comment(s.ReservedComments),
+	}
+	args := make([]*Struct, 0)
+
+	for _, f := range s.Functions {
+		method := &Method{
+			Name:    f.Name,
+			Comment: p.comment2Comment(f.ReservedComments),
+		}
+
+		switch {
+		case len(f.Arguments) == 1 && p.isStructLike(f.Arguments[0].Type):
+			method.Request = p.type2Type(f.Arguments[0].Type)
+			method.RequestName = f.Arguments[0].Name
+		case len(f.Arguments) > 0:
+			// the arguments is not a single struct, wrap them in a struct
+			st := p.structLike2struct(&parser.StructLike{
+				Name:   camel(s.Name) + camel(f.Name) + "Args",
+				Fields: f.Arguments,
+			}, SLSStruct)
+			args = append(args, st)
+			method.Request = &StructLikeType{
+				Name: st.Type.(*StructLikeType).Name,
+			}
+		}
+
+		if !f.Void {
+			method.Response = p.type2Type(f.FunctionType)
+		}
+
+		for _, t := range f.Throws {
+			method.Throws = append(method.Throws, &Member{
+				Field: t.Name,
+				Type:  p.type2Type(t.Type),
+				Index: int(t.ID),
+			})
+		}
+
+		for _, mode := range f.Annotations.Get(ThriftStreamingMode) {
+			switch mode {
+			case StreamingBidirectional:
+				method.RequestStream = true
+				method.ResponseStream = true
+			case StreamingClient:
+				method.RequestStream = true
+			case StreamingServer:
+				method.ResponseStream = true
+			}
+		}
+
+		service.Methods = append(service.Methods, method)
+	}
+
+	return service, args
+}
+
+func (p ThriftParser) isStructLike(t *parser.Type) bool {
+	_, ok := p.type2Type(t).(*StructLikeType)
+	return ok
+}
+
+// collectTypes collect the enums and typedefs declared in the file, they are
+// used to resolve the type names
+func (p *ThriftParser) collectTypes(thrift *parser.Thrift) {
+	p.enums = make(map[string]bool)
+	for _, e := range thrift.Enums {
+		p.enums[e.Name] = true
+	}
+	p.typedefs = make(map[string]*parser.Typedef)
+	for _, t := range thrift.Typedefs {
+		p.typedefs[t.Alias] = t
+	}
+}
+
+// parseIncludes parse the files included by thrift, the file is searched in
+// dir, the directory of the including file, and then [Context.ImportPaths].
+// The types declared in the included file are recorded with the qualified
+// name, such as `shared.Base`. The file which can not be found is skipped,
+// and the types in it are kept as the struct names
+func (p *ThriftParser) parseIncludes(thrift *parser.Thrift, dir string, loading map[string]bool) error {
+	for _, include := range thrift.Includes {
+		prefix := strings.TrimSuffix(filepath.Base(include.Path), filepath.Ext(include.Path))
+		// the package of the included file is named after the include name
+		// unless it declares the `go` namespace
+		p.goPackages[prefix] = prefix
+
+		filename := p.findInclude(dir, include.Path)
+		if filename == "" || loading[filename] {
+			continue
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		included, err := parser.ParseString(filename, string(data))
+		if err != nil {
+			return err
+		}
+
+		// the types of the included file are resolved in its own scope
+		sub := &ThriftParser{
+			ctx:        p.ctx,
+			includes:   make(map[string]Type),
+			goPackages: make(map[string]string),
+		}
+		loading[filename] = true
+		err = sub.parseIncludes(included, filepath.Dir(filename), loading)
+		delete(loading, filename)
+		if err != nil {
+			return err
+		}
+		sub.collectTypes(included)
+
+		for _, ns := range included.Namespaces {
+			if ns.Language == LangGo {
+				p.goPackages[prefix] = strings.ReplaceAll(ns.Name, ".", "/")
+			}
+		}
+		for _, e := range included.Enums {
+			p.includes[prefix+"."+e.Name] = &EnumType{
+				Name: prefix + "." + e.Name,
+			}
+		}
+		for _, t := range included.Typedefs {
+			p.includes[prefix+"."+t.Alias] = &TypedefType{
+				Name: prefix + "." + t.Alias,
+				Type: sub.type2Type(t.Type),
+			}
+		}
+		for _, sls := range [][]*parser.StructLike{included.Structs, included.Unions, included.Exceptions} {
+			for _, sl := range sls {
+				p.includes[prefix+"."+sl.Name] = &StructLikeType{
+					Name: prefix + "." + sl.Name,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p ThriftParser) findInclude(dir, path string) string {
+	dirs := p.ctx.ImportPaths
+	if dir != "" {
+		dirs = append([]string{dir}, dirs...)
+	}
+	for _, d := range dirs {
+		filename := filepath.Join(d, path)
+		if info, err := os.Stat(filename); err == nil && !info.IsDir() {
+			return filename
+		}
+	}
+	return ""
 }
 
 func (p ThriftParser) enum2struct(e *parser.Enum) *Struct {
@@ -61,13 +297,17 @@ func (p ThriftParser) enum2struct(e *parser.Enum) *Struct {
 		Type: &EnumType{
 			Name: e.Name,
 		},
+		Comment:     p.comment2Comment(e.ReservedComments),
+		Annotations: p.annotations2Annotations(e.Annotations),
 	}
 
 	for _, value := range e.Values {
 		member := &Member{
-			Field: value.Name,
-			Type:  s.Type,
-			Index: int(value.Value),
+			Field:       value.Name,
+			Type:        s.Type,
+			Index:       int(value.Value),
+			Comment:     p.comment2Comment(value.ReservedComments),
+			Annotations: p.annotations2Annotations(value.Annotations),
 		}
 		s.Members = append(s.Members, member)
 	}
@@ -80,6 +320,8 @@ func (p ThriftParser) structLike2struct(sl *parser.StructLike, source StructLike
 			Name:   sl.Name,
 			Source: source,
 		},
+		Comment:     p.comment2Comment(sl.ReservedComments),
+		Annotations: p.annotations2Annotations(sl.Annotations),
 	}
 
 	for _, field := range sl.Fields {
@@ -89,10 +331,13 @@ func (p ThriftParser) structLike2struct(sl *parser.StructLike, source StructLike
 		}
 
 		member := &Member{
-			Field:    field.Name,
-			Type:     t,
-			Index:    int(field.ID),
-			Optional: field.Requiredness == parser.FieldType_Optional,
+			Field:       field.Name,
+			Type:        t,
+			Index:       int(field.ID),
+			Optional:    field.Requiredness == parser.FieldType_Optional,
+			Default:     p.constValue2Value(field.Default),
+			Comment:     p.comment2Comment(field.ReservedComments),
+			Annotations: p.annotations2Annotations(field.Annotations),
 		}
 		s.Members = append(s.Members, member)
 	}
@@ -136,9 +381,116 @@ func (p ThriftParser) type2Type(t *parser.Type) Type {
 			Key: p.type2Type(t.ValueType),
 		}
 	default:
+		if p.enums[t.Name] {
+			return &EnumType{
+				Name: t.Name,
+			}
+		}
+		if typedef, ok := p.typedefs[t.Name]; ok {
+			return &TypedefType{
+				Name: t.Name,
+				Type: p.type2Type(typedef.Type),
+			}
+		}
+		if included, ok := p.includes[t.Name]; ok {
+			return copyIncludedType(included)
+		}
+		return &StructLikeType{
+			Name: t.Name,
+		}
+	}
+}
+
+func copyIncludedType(t Type) Type {
+	switch t := t.(type) {
+	case *EnumType:
+		return &EnumType{
+			Name: t.Name,
+		}
+	case *StructLikeType:
 		return &StructLikeType{
 			Name: t.Name,
 		}
+	case *TypedefType:
+		return &TypedefType{
+			Name: t.Name,
+			Type: t.Type,
+		}
+	}
+	return t
+}
+
+// comment2Comment convert the reserved comments to [Comment], thriftgo
+// only reserves the comments before the declaration
+func (p ThriftParser) comment2Comment(reserved string) Comment {
+	c := Comment{}
+	if reserved == "" {
+		return c
+	}
+	c.BeginningComments = strings.Split(reserved, "\n")
+	return c
+}
+
+func (p ThriftParser) annotations2Annotations(annotations parser.Annotations) Annotations {
+	if len(annotations) == 0 {
+		return nil
+	}
+	res := make(Annotations, 0, len(annotations))
+	for _, a := range annotations {
+		res = append(res, &Annotation{
+			Key:    a.Key,
+			Values: a.Values,
+		})
+	}
+	return res
+}
+
+func (p ThriftParser) constValue2Value(v *parser.ConstValue) *Value {
+	if v == nil || v.TypedValue == nil {
+		return nil
+	}
+
+	tv := v.TypedValue
+	switch v.Type {
+	case parser.ConstType_ConstDouble:
+		return &Value{
+			Kind: ValueDouble,
+			Text: strconv.FormatFloat(tv.GetDouble(), 'g', -1, 64),
+		}
+	case parser.ConstType_ConstInt:
+		return &Value{
+			Kind: ValueInt,
+			Text: strconv.FormatInt(tv.GetInt(), 10),
+		}
+	case parser.ConstType_ConstLiteral:
+		return &Value{
+			Kind: ValueString,
+			Text: tv.GetLiteral(),
+		}
+	case parser.ConstType_ConstIdentifier:
+		return &Value{
+			Kind: ValueIdentifier,
+			Text: tv.GetIdentifier(),
+		}
+	case parser.ConstType_ConstList:
+		value := &Value{
+			Kind: ValueList,
+		}
+		for _, item := range tv.List {
+			value.List = append(value.List, p.constValue2Value(item))
+		}
+		return value
+	case parser.ConstType_ConstMap:
+		value := &Value{
+			Kind: ValueMap,
+		}
+		for _, item := range tv.Map {
+			value.Map = append(value.Map, &MapValue{
+				Key:   p.constValue2Value(item.Key),
+				Value: p.constValue2Value(item.Value),
+			})
+		}
+		return value
 	}
 	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -242,7 +244,7 @@ union UUU {
 						},
 						{
 							Field: "e",
-							Type: &StructLikeType{
+							Type: &EnumType{
 								Name: "EEE",
 							},
 							Index: 3,
@@ -304,6 +306,258 @@ union UUU {
 			},
 			wantErr: false,
 		},
+		{
+			name: "service",
+			init: func(t *testing.T) ThriftParser {
+				return *NewThriftParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				data := []byte(`
+service Greeter {
+    string get(1: i64 id, 2: string key),
+}
+`)
+				reader := bytes.NewReader(data)
+				return args{
+					reader: reader,
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "GreeterGetArgs",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type:  Int64Val,
+							Index: 1,
+						},
+						{
+							Field: "key",
+							Type:  StringVal,
+							Index: 2,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "typedef and default",
+			init: func(t *testing.T) ThriftParser {
+				return *NewThriftParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				data := []byte(`
+typedef i64 UserId
+
+enum Status {
+    OK = 0,
+}
+
+struct User {
+    1: UserId id = 1,
+    2: Status status = Status.OK,
+    3: list<string> tags = ["a"],
+}
+`)
+				reader := bytes.NewReader(data)
+				return args{
+					reader: reader,
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &EnumType{
+						Name: "Status",
+					},
+					Members: []*Member{
+						{
+							Field: "OK",
+							Type: &EnumType{
+								Name: "Status",
+							},
+							Index: 0,
+						},
+					},
+				},
+				{
+					Type: &StructLikeType{
+						Name:   "User",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "id",
+							Type: &TypedefType{
+								Name: "UserId",
+								Type: Int64Val,
+							},
+							Index: 1,
+							Default: &Value{
+								Kind: ValueInt,
+								Text: "1",
+							},
+						},
+						{
+							Field: "status",
+							Type: &EnumType{
+								Name: "Status",
+							},
+							Index: 2,
+							Default: &Value{
+								Kind: ValueIdentifier,
+								Text: "Status.OK",
+							},
+						},
+						{
+							Field: "tags",
+							Type: &ArrayType{
+								ChildType: StringVal,
+							},
+							Index: 3,
+							Default: &Value{
+								Kind: ValueList,
+								List: []*Value{
+									{
+										Kind: ValueString,
+										Text: "a",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "comment and annotation",
+			init: func(t *testing.T) ThriftParser {
+				return *NewThriftParser(Context{})
+			},
+			args: func(t *testing.T) args {
+				data := []byte(`
+// struct comment
+struct S {
+    # field comment
+    1: string a (go.tag="json:\"a\"", api.body="a"),
+} (s.anno="v")
+`)
+				reader := bytes.NewReader(data)
+				return args{
+					reader: reader,
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "S",
+						Source: SLSStruct,
+					},
+					Members: []*Member{
+						{
+							Field: "a",
+							Type:  StringVal,
+							Index: 1,
+							Comment: Comment{
+								BeginningComments: []string{"// field comment"},
+							},
+							Annotations: Annotations{
+								{
+									Key:    "go.tag",
+									Values: []string{`json:"a"`},
+								},
+								{
+									Key:    "api.body",
+									Values: []string{"a"},
+								},
+							},
+						},
+					},
+					Comment: Comment{
+						BeginningComments: []string{"// struct comment"},
+					},
+					Annotations: Annotations{
+						{
+							Key:    "s.anno",
+							Values: []string{"v"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "include and exception",
+			init: func(t *testing.T) ThriftParser {
+				dir := t.TempDir()
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "shared.thrift"), []byte(`
+typedef i64 Id
+
+enum Status {
+    OK = 0,
+}
+
+struct Base {
+    1: Id id,
+}
+`), 0644))
+				return *NewThriftParser(Context{
+					ImportPaths: []string{dir},
+				})
+			},
+			args: func(t *testing.T) args {
+				data := []byte(`
+include "shared.thrift"
+
+exception NotFound {
+    1: shared.Base base,
+    2: shared.Status status,
+    3: shared.Id id,
+}
+`)
+				reader := bytes.NewReader(data)
+				return args{
+					reader: reader,
+				}
+			},
+			want1: []*Struct{
+				{
+					Type: &StructLikeType{
+						Name:   "NotFound",
+						Source: SLSException,
+					},
+					Members: []*Member{
+						{
+							Field: "base",
+							Type: &StructLikeType{
+								Name: "shared.Base",
+							},
+							Index: 1,
+						},
+						{
+							Field: "status",
+							Type: &EnumType{
+								Name: "shared.Status",
+							},
+							Index: 2,
+						},
+						{
+							Field: "id",
+							Type: &TypedefType{
+								Name: "shared.Id",
+								Type: Int64Val,
+							},
+							Index: 3,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
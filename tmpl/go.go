@@ -19,25 +19,114 @@ type {{ .Type.StructName }} struct { {{- if .Comment.InlineComment }} {{ .Commen
 {{- template "MEMBER" $member }}
 {{- end }}
 }
+{{- if .GoDefaults }}
+
+func New{{ .Type.StructName }}() *{{ .Type.StructName }} {
+	return &{{ .Type.StructName }}{
+	{{- range $member := .GoDefaults }}
+		{{ $member.FieldCamel }}: {{ $member.GoDefault }},
+	{{- end }}
+	}
+}
+{{- end }}
+{{- if .Type.IsException }}
+
+func (e *{{ .Type.StructName }}) Error() string {
+	return fmt.Sprintf("{{ .Type.StructName }}(%+v)", *e)
+}
+{{- end }}
 {{- end }}
 
 {{- define "ENUM" -}}
 {{- range $comment := .Comment.BeginningComments -}}
 {{- $comment }}
 {{ end -}}
-type {{ .Type.StructName }} int {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+type {{ .Type.StructName }} {{ if .StringEnum }}string{{ else }}int{{ end }} {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
 
 const (
-{{- range $member := .Members }} 
-	{{ $member.FieldCamel }} {{ $member.Go }} = {{ $member.Index }} {{ $member.Comment.InlineComment }} {{- end}}
+{{- range $member := .Members }}
+	{{- range $comment := $member.Comment.BeginningComments }}
+	{{ $comment }}
+	{{- end}}
+	{{ $member.FieldCamel }} {{ $member.Go }} = {{ $member.EnumValue }} {{ $member.Comment.InlineComment }} {{- end}}
 )
 {{- end }}
 
-{{- range $st := . -}}
+{{- define "TYPEDEF" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+type {{ .Name }} {{ .GoType }} {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- end }}
+
+{{- define "CONSTANT" }}
+	{{- range $comment := .Comment.BeginningComments }}
+	{{ $comment }}
+	{{- end}}
+	{{ .Name }} {{ .Type.Go }} = {{ .GoValue }} {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- end }}
+
+{{- define "SERVICE" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+type {{ .NameCamel }} interface { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $method := .Methods }}
+	{{- range $comment := .Comment.BeginningComments }}
+	{{ $comment }}
+	{{- end}}
+	{{ $method.NameCamel }}({{ $method.GoParams }}) {{ $method.GoResults }} {{ $method.Comment.InlineComment }} {{- end }}
+}
+{{- end }}
+
+{{- if .GoPackageName -}}
+package {{ .GoPackageName }}
+
+{{ end -}}
+
+{{- if or .GoImports .GoIncludes -}}
+import (
+{{- range $import := .GoImports }}
+	"{{ $import }}"
+{{- end }}
+{{- if and .GoImports .GoIncludes }}
+{{ end }}
+{{- range $include := .GoIncludes }}
+	{{ $include }}
+{{- end }}
+)
+
+{{ end -}}
+
+{{- range $typedef := .Typedefs -}}
+{{- template "TYPEDEF" $typedef }}
+
+{{ end }}
+{{- range $st := .Structs -}}
 {{- if eq $st.Type.GoStructType "enum" }}
 {{- template "ENUM" $st }}
 {{- else }}
 {{- template "STRUCT" $st }}
 {{- end }}
 
+{{ end }}
+{{- if .GoConstants -}}
+const (
+{{- range $constant := .GoConstants }}
+{{- template "CONSTANT" $constant }}
+{{- end }}
+)
+
+{{ end }}
+{{- if .GoVariables -}}
+var (
+{{- range $constant := .GoVariables }}
+{{- template "CONSTANT" $constant }}
+{{- end }}
+)
+
+{{ end }}
+{{- range $service := .Services }}
+{{- template "SERVICE" $service }}
+
 {{ end }}`
@@ -0,0 +1,72 @@
+package tmpl
+
+const Java = `
+{{- define "MEMBER" }}
+    {{- range $comment := .Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end }}
+    @JsonProperty({{ .JsonField }}) {{ .Java }} {{ .JavaField }}
+{{- end -}}
+
+{{- define "STRUCT" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+record {{ .Type.Java }}( {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $i, $member := .Members }}
+{{- template "MEMBER" $member }}{{ if ne $i $.LastMemberIndex }},{{ end }} {{ $member.Comment.InlineComment }}
+{{- end }}
+{{- if .Members }}
+{{ end -}}
+) {
+}
+{{- end }}
+
+{{- define "ENUM" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+enum {{ .Type.Java }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $member := .Members }}
+    {{- range $comment := $member.Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end }}
+    {{- if $.StringEnum }}
+    @JsonProperty({{ $member.JsonEnumValue }})
+    {{ $member.EnumConstant }}, {{ $member.Comment.InlineComment }}
+    {{- else }}
+    {{ $member.EnumConstant }}({{ $member.EnumValue }}), {{ $member.Comment.InlineComment }}
+    {{- end }}
+{{- end }}
+{{- if not .StringEnum }}
+    ;
+
+    private final int value;
+
+    {{ .Type.Java }}(int value) {
+        this.value = value;
+    }
+
+    @JsonValue
+    public int getValue() {
+        return value;
+    }
+{{- end }}
+}
+{{- end }}
+
+{{- if .Package -}}
+package {{ .Package }};
+
+{{ end -}}
+{{- range $import := .JavaImports -}}
+import {{ $import }};
+{{ end }}
+{{ range $st := .Structs }}
+{{- if $st.IsEnum }}
+{{- template "ENUM" $st -}}
+{{- else -}}
+{{- template "STRUCT" $st }}
+{{- end }}
+
+{{ end }}`
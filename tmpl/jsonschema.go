@@ -0,0 +1,61 @@
+package tmpl
+
+const JsonSchema = `
+{{- define "STRUCT" -}}
+{
+    "type": "object",
+    {{- if .Comment.JsonDescription }}
+    "description": {{ .Comment.JsonDescription }},
+    {{- end }}
+    "properties": {
+    {{- range $i, $member := .Members }}
+        {{- if $i }},{{ end }}
+        {{ $member.JsonField }}: {{ $member.JsonSchema }}
+    {{- end }}
+    },
+    {{- if eq .Type.ThriftStructType "union" }}
+    "minProperties": 1,
+    "maxProperties": 1,
+    {{- end }}
+    "required": [
+    {{- range $i, $field := .RequiredFields }}
+        {{- if $i }},{{ end }}
+        {{ $field }}
+    {{- end }}
+    ]
+}
+{{- end }}
+
+{{- define "ENUM" -}}
+{
+    "type": {{ if .StringEnum }}"string"{{ else }}"integer"{{ end }},
+    {{- if .Comment.JsonDescription }}
+    "description": {{ .Comment.JsonDescription }},
+    {{- end }}
+    "enum": [
+    {{- range $i, $member := .Members }}
+        {{- if $i }},{{ end }}
+        {{ $member.JsonEnumValue }}
+    {{- end }}
+    ]
+}
+{{- end -}}
+
+{
+    "$schema": "https://json-schema.org/draft/2020-12/schema",
+    {{- with .JsonSchemaRoot }}
+    {{ . }},
+    {{- end }}
+    "$defs": {
+    {{- range $i, $st := .Structs }}
+        {{- if $i }},{{ end }}
+        "{{ $st.Type.StructName }}":
+        {{- if eq $st.Type.JsonSchemaStructType "enum" }}
+        {{- template "ENUM" $st -}}
+        {{- else -}}
+        {{- template "STRUCT" $st }}
+        {{- end }}
+    {{- end }}
+    }
+}
+`
@@ -0,0 +1,79 @@
+package tmpl
+
+const Kotlin = `
+{{- define "MEMBER" }}
+    {{- range $comment := .Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end }}
+    @SerialName({{ .KotlinSerialName }})
+    val {{ .KotlinField }}: {{ .Kotlin }}{{ if .Optional }} = null{{ end }}, {{ .Comment.InlineComment }} {{- end -}}
+
+{{- define "STRUCT" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+@Serializable
+{{- if .Members }}
+data class {{ .Type.Kotlin }}( {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $member := .Members }}
+{{- template "MEMBER" $member }}
+{{- end }}
+)
+{{- else }}
+class {{ .Type.Kotlin }} {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- end }}
+{{- end }}
+
+{{- define "ENUM" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+{{- if .StringEnum -}}
+@Serializable
+{{- else -}}
+@Serializable(with = {{ .Type.Kotlin }}.Serializer::class)
+{{- end }}
+enum class {{ .Type.Kotlin }}{{ if not .StringEnum }}(val value: Int){{ end }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $member := .Members }}
+    {{- range $comment := $member.Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end }}
+    {{- if $.StringEnum }}
+    @SerialName({{ $member.KotlinSerialName }})
+    {{ $member.EnumConstant }}, {{ $member.Comment.InlineComment }}
+    {{- else }}
+    {{ $member.EnumConstant }}({{ $member.EnumValue }}), {{ $member.Comment.InlineComment }}
+    {{- end }}
+{{- end }}
+{{- if not .StringEnum }}
+    ;
+
+    object Serializer : KSerializer<{{ .Type.Kotlin }}> {
+        override val descriptor = PrimitiveSerialDescriptor("{{ .Type.Kotlin }}", PrimitiveKind.INT)
+
+        override fun serialize(encoder: Encoder, value: {{ .Type.Kotlin }}) = encoder.encodeInt(value.value)
+
+        override fun deserialize(decoder: Decoder): {{ .Type.Kotlin }} {
+            val value = decoder.decodeInt()
+            return values().first { it.value == value }
+        }
+    }
+{{- end }}
+}
+{{- end }}
+
+{{- if .Package -}}
+package {{ .Package }}
+
+{{ end -}}
+{{- range $import := .KotlinImports -}}
+import {{ $import }}
+{{ end }}
+{{ range $st := .Structs }}
+{{- if $st.IsEnum }}
+{{- template "ENUM" $st -}}
+{{- else -}}
+{{- template "STRUCT" $st }}
+{{- end }}
+
+{{ end }}`
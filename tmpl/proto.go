@@ -1,11 +1,36 @@
 package tmpl
 
 const Proto = `
-{{- define "MEMBER" }}
+{{- define "FIELD" }}
+    {{- range $comment := .Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end}}
+    {{ if .ProtoOptional }}optional {{ end }}{{.Proto}} {{.Field}} = {{.Index}}; {{ .Comment.InlineComment }} {{- end -}}
+
+{{- define "ONEOF_FIELD" }}
+        {{- range $comment := .Comment.BeginningComments }}
+        {{ $comment }}
+        {{- end}}
+        {{.Proto}} {{.Field}} = {{.Index}}; {{ .Comment.InlineComment }} {{- end -}}
+
+{{- define "ONEOF" }}
     {{- range $comment := .Comment.BeginningComments }}
     {{ $comment }}
     {{- end}}
-    {{.Proto}} {{.Field}} = {{.Index}}; {{ .Comment.InlineComment }} {{- end -}}
+    oneof {{.Field}} { {{- if .Oneof.Comment.InlineComment }} {{ .Oneof.Comment.InlineComment }} {{- end }}
+    {{- range $member := .Oneof.Members }}
+    {{- template "ONEOF_FIELD" $member }}
+    {{- end }}
+    }
+{{- end -}}
+
+{{- define "MEMBER" }}
+    {{- if .Oneof }}
+    {{- template "ONEOF" . }}
+    {{- else }}
+    {{- template "FIELD" . }}
+    {{- end }}
+{{- end -}}
 
 {{- define "STRUCT" -}}
 {{- range $comment := .Comment.BeginningComments -}}
@@ -23,16 +48,64 @@ message {{ .Type.StructName }} { {{- if .Comment.InlineComment }} {{ .Comment.In
 {{- $comment }}
 {{ end -}}
 enum {{ .Type.StructName }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
-{{- range $member := .Members }} 
+{{- range $member := .ProtoMembers }}
+    {{- range $comment := $member.Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end}}
     {{ $member.Field }} = {{ $member.Index }}; {{ $member.Comment.InlineComment}} {{- end}}
 }
 {{- end }}
 
-{{- range $st := . }}
-{{- if eq $st.Type.ProtoStructType "enum" }}
-{{- template "ENUM" $st -}}
-{{- else -}}
+{{- define "SERVICE" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+service {{ .Name }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $method := .Methods }}
+    {{- range $comment := .Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end}}
+    rpc {{ $method.Name }} ({{ $method.ProtoRequest }}) returns ({{ $method.ProtoResponse }}); {{ $method.Comment.InlineComment }} {{- end }}
+}
+{{- end }}
+
+{{- if or .HasHeader .Syntax .ProtoImports -}}
+syntax = "{{ .ProtoSyntax }}";
+
+{{ if .ProtoImports -}}
+{{ range $import := .ProtoImports -}}
+import "{{ $import }}";
+{{ end }}
+{{ end -}}
+{{ if .Package -}}
+package {{ .Package }};
+
+{{ end -}}
+{{ if .GoPackage -}}
+option go_package = "{{ .GoPackage }}";
+
+{{ end -}}
+{{ end -}}
+
+{{ range $typedef := .Typedefs -}}
+{{ range $comment := .Comment.BeginningComments -}}
+{{ $comment }}
+{{ end -}}
+// typedef {{ $typedef.Type.Proto }} {{ $typedef.Name }}
+{{ end -}}
+{{ if .Typedefs }}
+{{ end }}
+{{- range $st := .Structs }}
+{{- if $st.Oneof }}
+{{- else if eq $st.Type.ProtoStructType "enum" }}
+{{- template "ENUM" $st }}
+
+{{ else -}}
 {{- template "STRUCT" $st }}
+
+{{ end }}
 {{- end }}
+{{- range $service := .Services }}
+{{- template "SERVICE" $service }}
 
 {{ end }}`
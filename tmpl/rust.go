@@ -0,0 +1,57 @@
+package tmpl
+
+const Rust = `
+{{- define "MEMBER" }}
+    {{- range $comment := .Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end }}
+    {{- with .RustSerde }}
+    {{ . }}
+    {{- end }}
+    pub {{ .RustField }}: {{ .Rust }}, {{ .Comment.InlineComment }} {{- end -}}
+
+{{- define "STRUCT" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+{{ .RustDerive }}
+pub struct {{ .Type.Rust }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $member := .Members }}
+{{- template "MEMBER" $member }}
+{{- end }}
+}
+{{- end }}
+
+{{- define "ENUM" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+{{ .RustDerive }}
+{{- if not .StringEnum }}
+#[repr(i32)]
+{{- end }}
+pub enum {{ .Type.Rust }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $member := .Members }}
+    {{- range $comment := $member.Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end }}
+    {{- if $.StringEnum }}
+    {{- with $member.RustSerde }}
+    {{ . }}
+    {{- end }}
+    {{- end }}
+    {{ $member.RustVariant }}{{ if not $.StringEnum }} = {{ $member.EnumValue }}{{ end }}, {{ $member.Comment.InlineComment }} {{- end }}
+}
+{{- end }}
+
+{{- range $import := .RustImports -}}
+use {{ $import }};
+{{ end }}
+{{ range $st := .RustStructs }}
+{{- if $st.IsEnum }}
+{{- template "ENUM" $st -}}
+{{- else -}}
+{{- template "STRUCT" $st }}
+{{- end }}
+
+{{ end }}`
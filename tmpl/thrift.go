@@ -5,17 +5,28 @@ const Thrift = `
     {{- range $comment := .Comment.BeginningComments }}
     {{ $comment }}
     {{- end}}
-    {{.Index}}: {{.Thrift}} {{.Field}}, {{ .Comment.InlineComment }} {{- end -}}
+    {{.Index}}: {{ if .Optional }}optional {{ end }}{{.Thrift}} {{.Field}}{{ if .Default }} = {{ .Default.Thrift }}{{ end }}{{ .Annotations.Thrift }}, {{ .Comment.InlineComment }} {{- end -}}
+
+{{- define "UNION_MEMBER" }}
+    {{- range $comment := .Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end}}
+    {{.Index}}: {{.Thrift}} {{.Field}}{{ if .Default }} = {{ .Default.Thrift }}{{ end }}{{ .Annotations.Thrift }}, {{ .Comment.InlineComment }} {{- end -}}
 
 {{- define "STRUCT" -}}
 {{- range $comment := .Comment.BeginningComments -}}
 {{- $comment }}
 {{ end -}}
 {{- .Type.ThriftStructType }} {{ .Type.StructName }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- $union := eq .Type.ThriftStructType "union" }}
 {{- range $member := .Members }}
+{{- if $union }}
+{{- template "UNION_MEMBER" $member }}
+{{- else }}
 {{- template "MEMBER" $member }}
 {{- end }}
-}
+{{- end }}
+}{{ .Annotations.Thrift }}
 {{- end }}
 
 {{- define "ENUM" -}}
@@ -23,16 +34,71 @@ const Thrift = `
 {{- $comment }}
 {{ end -}}
 enum {{ .Type.StructName }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
-{{- range $member := .Members }} 
-    {{ $member.Field }} = {{ $member.Index }}; {{ $member.Comment.InlineComment}} {{- end}}
+{{- range $member := .Members }}
+    {{- range $comment := $member.Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end}}
+    {{ $member.Field }} = {{ $member.Index }}{{ $member.Annotations.Thrift }}; {{ $member.Comment.InlineComment}} {{- end}}
+}{{ .Annotations.Thrift }}
+{{- end }}
+
+{{- define "TYPEDEF" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+typedef {{ .Type.Thrift }} {{ .Name }} {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- end }}
+
+{{- define "CONSTANT" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+const {{ .Type.Thrift }} {{ .Name }} = {{ .Value.Thrift }} {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- end }}
+
+{{- define "SERVICE" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+service {{ .Name }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $method := .Methods }}
+    {{- range $comment := .Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end}}
+    {{ $method.ThriftResponse }} {{ $method.Name }}({{ $method.ThriftArgs }}){{ $method.ThriftThrows }}{{ $method.ThriftAnnotation }}, {{ $method.Comment.InlineComment }} {{- end }}
 }
 {{- end }}
 
-{{- range $st := . }}
+{{- if .HasHeader -}}
+{{ range $include := .Includes -}}
+include "{{ $include }}"
+{{ end }}
+{{- if and .Includes .ThriftNamespaces }}
+{{ end }}
+{{- range $namespace := .ThriftNamespaces -}}
+namespace {{ $namespace.Language }} {{ $namespace.Name }}
+{{ end }}
+{{ end -}}
+
+{{- range $typedef := .Typedefs }}
+{{- template "TYPEDEF" $typedef }}
+{{ end }}
+{{- if .Typedefs }}
+{{ end }}
+{{- range $st := .Structs }}
 {{- if eq $st.Type.ThriftStructType "enum" }}
 {{- template "ENUM" $st -}}
 {{- else -}}
 {{- template "STRUCT" $st }}
 {{- end }}
 
+{{ end }}
+{{- range $constant := .Constants }}
+{{- template "CONSTANT" $constant }}
+{{ end }}
+{{- if .Constants }}
+{{ end }}
+{{- range $service := .Services }}
+{{- template "SERVICE" $service }}
+
 {{ end }}`
@@ -0,0 +1,38 @@
+package tmpl
+
+const TypeScript = `
+{{- define "MEMBER" }}
+    {{- range $comment := .Comment.BeginningComments }}
+    {{ $comment }}
+    {{- end}}
+    {{.TypeScriptField}}{{ if .Optional }}?{{ end }}: {{.TypeScript}}; {{ .Comment.InlineComment }} {{- end -}}
+
+{{- define "STRUCT" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+export interface {{ .Type.TypeScript }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $member := .Members }}
+{{- template "MEMBER" $member }}
+{{- end }}
+}
+{{- end }}
+
+{{- define "ENUM" -}}
+{{- range $comment := .Comment.BeginningComments -}}
+{{- $comment }}
+{{ end -}}
+export enum {{ .Type.TypeScript }} { {{- if .Comment.InlineComment }} {{ .Comment.InlineComment }} {{- end }}
+{{- range $member := .Members }}
+    {{ $member.Field }} = {{ $member.EnumValue }}, {{ $member.Comment.InlineComment}} {{- end}}
+}
+{{- end }}
+
+{{- range $st := .Structs }}
+{{- if eq $st.Type.TypeScriptStructType "enum" }}
+{{- template "ENUM" $st -}}
+{{- else -}}
+{{- template "STRUCT" $st }}
+{{- end }}
+
+{{ end }}`
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/iancoleman/strcase"
 )
 
 // StructLikeSource which type of [StructLikeType]
@@ -11,9 +13,10 @@ type StructLikeSource int
 
 // const values of [StructLikeSource]
 const (
-	SLSUnknown StructLikeSource = 0 // Unknown type
-	SLSStruct  StructLikeSource = 1 // Struct type
-	SLSUnion   StructLikeSource = 2 // Union type
+	SLSUnknown   StructLikeSource = 0 // Unknown type
+	SLSStruct    StructLikeSource = 1 // Struct type
+	SLSUnion     StructLikeSource = 2 // Union type
+	SLSException StructLikeSource = 3 // Exception type
 )
 
 var (
@@ -36,6 +39,8 @@ var (
 	SetVal        Type = &SetType{}
 	EnumVal       Type = &EnumType{}
 	StructLikeVal Type = &StructLikeType{}
+	TimestampVal  Type = &TimestampType{}
+	DurationVal   Type = &DurationType{}
 )
 
 type Type interface {
@@ -43,168 +48,280 @@ type Type interface {
 	Go() string
 	Proto() string
 	Thrift() string
+	TypeScript() string
+	JsonSchema() string
+	Rust() string
+	Java() string
+	Kotlin() string
 	IsBasicType() bool
 }
 
 // AnyType cover json null value, go any value, proto any value
 type AnyType struct{}
 
-func (v AnyType) Json() string      { return StrNull }
-func (v AnyType) Go() string        { return StrAny }
-func (v AnyType) Proto() string     { return StrPbAny }
-func (v AnyType) Thrift() string    { return StrBinary }
-func (v AnyType) Value() string     { return StrNil }
-func (v AnyType) IsBasicType() bool { return false }
+func (v AnyType) Json() string       { return StrNull }
+func (v AnyType) Go() string         { return StrAny }
+func (v AnyType) Proto() string      { return StrPbAny }
+func (v AnyType) Thrift() string     { return StrBinary }
+func (v AnyType) TypeScript() string { return StrAny }
+func (v AnyType) JsonSchema() string { return `{}` }
+func (v AnyType) Rust() string       { return StrRustValue }
+func (v AnyType) Java() string       { return StrJavaObject }
+func (v AnyType) Kotlin() string     { return StrKotlinJsonElement }
+func (v AnyType) Value() string      { return StrNil }
+func (v AnyType) IsBasicType() bool  { return false }
 
 type BoolType struct {
 	V bool
 }
 
-func (v BoolType) Json() string      { return StrBool }
-func (v BoolType) Go() string        { return StrBool }
-func (v BoolType) Proto() string     { return StrBool }
-func (v BoolType) Thrift() string    { return StrBool }
-func (v BoolType) Value() string     { return strconv.FormatBool(v.V) }
-func (v BoolType) IsBasicType() bool { return true }
+func (v BoolType) Json() string       { return StrBool }
+func (v BoolType) Go() string         { return StrBool }
+func (v BoolType) Proto() string      { return StrBool }
+func (v BoolType) Thrift() string     { return StrBool }
+func (v BoolType) TypeScript() string { return StrBoolean }
+func (v BoolType) JsonSchema() string { return `{"type": "boolean"}` }
+func (v BoolType) Rust() string       { return StrBool }
+func (v BoolType) Java() string       { return StrJavaBoolean }
+func (v BoolType) Kotlin() string     { return StrJavaBoolean }
+func (v BoolType) Value() string      { return strconv.FormatBool(v.V) }
+func (v BoolType) IsBasicType() bool  { return true }
 
 type Float32Type struct {
 	V float32
 }
 
-func (v Float32Type) Json() string      { return StrNumber }
-func (v Float32Type) Go() string        { return StrFloat32 }
-func (v Float32Type) Proto() string     { return StrFloat }
-func (v Float32Type) Thrift() string    { return StrDouble }
-func (v Float32Type) Value() string     { return strconv.FormatFloat(float64(v.V), 'f', -1, 32) }
-func (v Float32Type) IsBasicType() bool { return true }
+func (v Float32Type) Json() string       { return StrNumber }
+func (v Float32Type) Go() string         { return StrFloat32 }
+func (v Float32Type) Proto() string      { return StrFloat }
+func (v Float32Type) Thrift() string     { return StrDouble }
+func (v Float32Type) TypeScript() string { return StrNumber }
+func (v Float32Type) JsonSchema() string { return `{"type": "number"}` }
+func (v Float32Type) Rust() string       { return StrF32 }
+func (v Float32Type) Java() string       { return StrJavaFloat }
+func (v Float32Type) Kotlin() string     { return StrJavaFloat }
+func (v Float32Type) Value() string      { return strconv.FormatFloat(float64(v.V), 'f', -1, 32) }
+func (v Float32Type) IsBasicType() bool  { return true }
 
 type Float64Type struct {
 	V float64
 }
 
-func (v Float64Type) Json() string      { return StrNumber }
-func (v Float64Type) Go() string        { return StrFloat64 }
-func (v Float64Type) Proto() string     { return StrDouble }
-func (v Float64Type) Thrift() string    { return StrDouble }
-func (v Float64Type) Value() string     { return strconv.FormatFloat(v.V, 'f', -1, 64) }
-func (v Float64Type) IsBasicType() bool { return true }
+func (v Float64Type) Json() string       { return StrNumber }
+func (v Float64Type) Go() string         { return StrFloat64 }
+func (v Float64Type) Proto() string      { return StrDouble }
+func (v Float64Type) Thrift() string     { return StrDouble }
+func (v Float64Type) TypeScript() string { return StrNumber }
+func (v Float64Type) JsonSchema() string { return `{"type": "number"}` }
+func (v Float64Type) Rust() string       { return StrF64 }
+func (v Float64Type) Java() string       { return StrJavaDouble }
+func (v Float64Type) Kotlin() string     { return StrJavaDouble }
+func (v Float64Type) Value() string      { return strconv.FormatFloat(v.V, 'f', -1, 64) }
+func (v Float64Type) IsBasicType() bool  { return true }
 
 type StringType struct {
 	V string
 }
 
-func (v StringType) Json() string      { return StrString }
-func (v StringType) Go() string        { return StrString }
-func (v StringType) Proto() string     { return StrString }
-func (v StringType) Thrift() string    { return StrString }
-func (v StringType) Value() string     { return v.V }
-func (v StringType) IsBasicType() bool { return true }
+func (v StringType) Json() string       { return StrString }
+func (v StringType) Go() string         { return StrString }
+func (v StringType) Proto() string      { return StrString }
+func (v StringType) Thrift() string     { return StrString }
+func (v StringType) TypeScript() string { return StrString }
+func (v StringType) JsonSchema() string { return `{"type": "string"}` }
+func (v StringType) Rust() string       { return StrUpperString }
+func (v StringType) Java() string       { return StrUpperString }
+func (v StringType) Kotlin() string     { return StrUpperString }
+func (v StringType) Value() string      { return v.V }
+func (v StringType) IsBasicType() bool  { return true }
 
 type ArrayType struct {
 	ChildType Type
 }
 
-func (v ArrayType) Json() string      { return "[]" + v.ChildType.Json() }
-func (v ArrayType) Go() string        { return "[]" + v.ChildType.Go() }
-func (v ArrayType) Proto() string     { return StrRepeated + " " + v.ChildType.Proto() }
-func (v ArrayType) Thrift() string    { return StrList + "<" + v.ChildType.Thrift() + ">" }
+func (v ArrayType) Json() string       { return "[]" + v.ChildType.Json() }
+func (v ArrayType) Go() string         { return "[]" + v.ChildType.Go() }
+func (v ArrayType) Proto() string      { return StrRepeated + " " + v.ChildType.Proto() }
+func (v ArrayType) Thrift() string     { return StrList + "<" + v.ChildType.Thrift() + ">" }
+func (v ArrayType) TypeScript() string { return tsArray(v.ChildType.TypeScript()) }
+func (v ArrayType) JsonSchema() string {
+	return fmt.Sprintf(`{"type": "array", "items": %s}`, v.ChildType.JsonSchema())
+}
+func (v ArrayType) Rust() string      { return StrVec + "<" + v.ChildType.Rust() + ">" }
+func (v ArrayType) Java() string      { return StrJavaList + "<" + v.ChildType.Java() + ">" }
+func (v ArrayType) Kotlin() string    { return StrJavaList + "<" + v.ChildType.Kotlin() + ">" }
 func (v ArrayType) IsBasicType() bool { return false }
 
 type Int8Type struct {
 	V int8
 }
 
-func (v Int8Type) Json() string      { return StrNumber }
-func (v Int8Type) Go() string        { return StrInt8 }
-func (v Int8Type) Proto() string     { return StrInt32 }
-func (v Int8Type) Thrift() string    { return StrByte }
-func (v Int8Type) Value() string     { return strconv.FormatInt(int64(v.V), 10) }
-func (v Int8Type) IsBasicType() bool { return true }
+func (v Int8Type) Json() string       { return StrNumber }
+func (v Int8Type) Go() string         { return StrInt8 }
+func (v Int8Type) Proto() string      { return StrInt32 }
+func (v Int8Type) Thrift() string     { return StrByte }
+func (v Int8Type) TypeScript() string { return StrNumber }
+func (v Int8Type) JsonSchema() string { return jsonSchemaInteger }
+func (v Int8Type) Rust() string       { return StrI8 }
+func (v Int8Type) Java() string       { return StrJavaByte }
+func (v Int8Type) Kotlin() string     { return StrJavaByte }
+func (v Int8Type) Value() string      { return strconv.FormatInt(int64(v.V), 10) }
+func (v Int8Type) IsBasicType() bool  { return true }
 
 type Int16Type struct {
 	V int16
 }
 
-func (v Int16Type) Json() string      { return StrNumber }
-func (v Int16Type) Go() string        { return StrInt16 }
-func (v Int16Type) Proto() string     { return StrInt32 }
-func (v Int16Type) Thrift() string    { return StrI16 }
-func (v Int16Type) Value() string     { return strconv.FormatInt(int64(v.V), 10) }
-func (v Int16Type) IsBasicType() bool { return true }
+func (v Int16Type) Json() string       { return StrNumber }
+func (v Int16Type) Go() string         { return StrInt16 }
+func (v Int16Type) Proto() string      { return StrInt32 }
+func (v Int16Type) Thrift() string     { return StrI16 }
+func (v Int16Type) TypeScript() string { return StrNumber }
+func (v Int16Type) JsonSchema() string { return jsonSchemaInteger }
+func (v Int16Type) Rust() string       { return StrI16 }
+func (v Int16Type) Java() string       { return StrJavaShort }
+func (v Int16Type) Kotlin() string     { return StrJavaShort }
+func (v Int16Type) Value() string      { return strconv.FormatInt(int64(v.V), 10) }
+func (v Int16Type) IsBasicType() bool  { return true }
 
 type Int32Type struct {
 	V int32
 }
 
-func (v Int32Type) Json() string      { return StrNumber }
-func (v Int32Type) Go() string        { return StrInt32 }
-func (v Int32Type) Proto() string     { return StrInt32 }
-func (v Int32Type) Thrift() string    { return StrI32 }
-func (v Int32Type) Value() string     { return strconv.FormatInt(int64(v.V), 10) }
-func (v Int32Type) IsBasicType() bool { return true }
+func (v Int32Type) Json() string       { return StrNumber }
+func (v Int32Type) Go() string         { return StrInt32 }
+func (v Int32Type) Proto() string      { return StrInt32 }
+func (v Int32Type) Thrift() string     { return StrI32 }
+func (v Int32Type) TypeScript() string { return StrNumber }
+func (v Int32Type) JsonSchema() string { return jsonSchemaInteger }
+func (v Int32Type) Rust() string       { return StrI32 }
+func (v Int32Type) Java() string       { return StrJavaInteger }
+func (v Int32Type) Kotlin() string     { return StrKotlinInt }
+func (v Int32Type) Value() string      { return strconv.FormatInt(int64(v.V), 10) }
+func (v Int32Type) IsBasicType() bool  { return true }
 
 type Int64Type struct {
 	V int64
 }
 
-func (v Int64Type) Json() string      { return StrNumber }
-func (v Int64Type) Go() string        { return StrInt64 }
-func (v Int64Type) Proto() string     { return StrInt64 }
-func (v Int64Type) Thrift() string    { return StrI64 }
-func (v Int64Type) Value() string     { return strconv.FormatInt(int64(v.V), 10) }
-func (v Int64Type) IsBasicType() bool { return true }
+func (v Int64Type) Json() string       { return StrNumber }
+func (v Int64Type) Go() string         { return StrInt64 }
+func (v Int64Type) Proto() string      { return StrInt64 }
+func (v Int64Type) Thrift() string     { return StrI64 }
+func (v Int64Type) TypeScript() string { return StrNumber }
+func (v Int64Type) JsonSchema() string { return jsonSchemaInteger }
+func (v Int64Type) Rust() string       { return StrI64 }
+func (v Int64Type) Java() string       { return StrJavaLong }
+func (v Int64Type) Kotlin() string     { return StrJavaLong }
+func (v Int64Type) Value() string      { return strconv.FormatInt(int64(v.V), 10) }
+func (v Int64Type) IsBasicType() bool  { return true }
 
 type Uint8Type struct {
 	V int8
 }
 
-func (v Uint8Type) Json() string      { return StrNumber }
-func (v Uint8Type) Go() string        { return StrUint8 }
-func (v Uint8Type) Proto() string     { return StrUint32 }
-func (v Uint8Type) Thrift() string    { return StrByte }
-func (v Uint8Type) Value() string     { return strconv.FormatInt(int64(v.V), 10) }
-func (v Uint8Type) IsBasicType() bool { return true }
+func (v Uint8Type) Json() string       { return StrNumber }
+func (v Uint8Type) Go() string         { return StrUint8 }
+func (v Uint8Type) Proto() string      { return StrUint32 }
+func (v Uint8Type) Thrift() string     { return StrByte }
+func (v Uint8Type) TypeScript() string { return StrNumber }
+func (v Uint8Type) JsonSchema() string { return jsonSchemaInteger }
+func (v Uint8Type) Rust() string       { return StrU8 }
+func (v Uint8Type) Java() string       { return StrJavaShort }
+func (v Uint8Type) Kotlin() string     { return StrKotlinUByte }
+func (v Uint8Type) Value() string      { return strconv.FormatInt(int64(v.V), 10) }
+func (v Uint8Type) IsBasicType() bool  { return true }
 
 type Uint16Type struct {
 	V int16
 }
 
-func (v Uint16Type) Json() string      { return StrNumber }
-func (v Uint16Type) Go() string        { return StrUint16 }
-func (v Uint16Type) Proto() string     { return StrUint32 }
-func (v Uint16Type) Thrift() string    { return StrI16 }
-func (v Uint16Type) Value() string     { return strconv.FormatInt(int64(v.V), 10) }
-func (v Uint16Type) IsBasicType() bool { return true }
+func (v Uint16Type) Json() string       { return StrNumber }
+func (v Uint16Type) Go() string         { return StrUint16 }
+func (v Uint16Type) Proto() string      { return StrUint32 }
+func (v Uint16Type) Thrift() string     { return StrI16 }
+func (v Uint16Type) TypeScript() string { return StrNumber }
+func (v Uint16Type) JsonSchema() string { return jsonSchemaInteger }
+func (v Uint16Type) Rust() string       { return StrU16 }
+func (v Uint16Type) Java() string       { return StrJavaInteger }
+func (v Uint16Type) Kotlin() string     { return StrKotlinUShort }
+func (v Uint16Type) Value() string      { return strconv.FormatInt(int64(v.V), 10) }
+func (v Uint16Type) IsBasicType() bool  { return true }
 
 type Uint32Type struct {
 	V int32
 }
 
-func (v Uint32Type) Json() string      { return StrNumber }
-func (v Uint32Type) Go() string        { return StrUint32 }
-func (v Uint32Type) Proto() string     { return StrUint32 }
-func (v Uint32Type) Thrift() string    { return StrI32 }
-func (v Uint32Type) Value() string     { return strconv.FormatInt(int64(v.V), 10) }
-func (v Uint32Type) IsBasicType() bool { return true }
+func (v Uint32Type) Json() string       { return StrNumber }
+func (v Uint32Type) Go() string         { return StrUint32 }
+func (v Uint32Type) Proto() string      { return StrUint32 }
+func (v Uint32Type) Thrift() string     { return StrI32 }
+func (v Uint32Type) TypeScript() string { return StrNumber }
+func (v Uint32Type) JsonSchema() string { return jsonSchemaInteger }
+func (v Uint32Type) Rust() string       { return StrU32 }
+func (v Uint32Type) Java() string       { return StrJavaLong }
+func (v Uint32Type) Kotlin() string     { return StrKotlinUInt }
+func (v Uint32Type) Value() string      { return strconv.FormatInt(int64(v.V), 10) }
+func (v Uint32Type) IsBasicType() bool  { return true }
 
 type Uint64Type struct {
 	V int64
 }
 
-func (v Uint64Type) Json() string      { return StrNumber }
-func (v Uint64Type) Go() string        { return StrUint64 }
-func (v Uint64Type) Proto() string     { return StrUint64 }
-func (v Uint64Type) Thrift() string    { return StrI64 }
-func (v Uint64Type) Value() string     { return strconv.FormatInt(int64(v.V), 10) }
-func (v Uint64Type) IsBasicType() bool { return true }
+func (v Uint64Type) Json() string       { return StrNumber }
+func (v Uint64Type) Go() string         { return StrUint64 }
+func (v Uint64Type) Proto() string      { return StrUint64 }
+func (v Uint64Type) Thrift() string     { return StrI64 }
+func (v Uint64Type) TypeScript() string { return StrNumber }
+func (v Uint64Type) JsonSchema() string { return jsonSchemaInteger }
+func (v Uint64Type) Rust() string       { return StrU64 }
+func (v Uint64Type) Java() string       { return StrJavaLong }
+func (v Uint64Type) Kotlin() string     { return StrKotlinULong }
+func (v Uint64Type) Value() string      { return strconv.FormatInt(int64(v.V), 10) }
+func (v Uint64Type) IsBasicType() bool  { return true }
 
 type BinaryType struct{}
 
-func (v BinaryType) Json() string      { return StrString }
-func (v BinaryType) Go() string        { return "[]" + StrByte }
-func (v BinaryType) Proto() string     { return StrBytes }
-func (v BinaryType) Thrift() string    { return StrBinary }
-func (v BinaryType) IsBasicType() bool { return false }
+func (v BinaryType) Json() string       { return StrString }
+func (v BinaryType) Go() string         { return "[]" + StrByte }
+func (v BinaryType) Proto() string      { return StrBytes }
+func (v BinaryType) Thrift() string     { return StrBinary }
+func (v BinaryType) TypeScript() string { return StrString }
+func (v BinaryType) JsonSchema() string { return `{"type": "string", "contentEncoding": "base64"}` }
+func (v BinaryType) Rust() string       { return StrVec + "<" + StrU8 + ">" }
+func (v BinaryType) Java() string       { return StrByte + "[]" }
+func (v BinaryType) Kotlin() string     { return StrKotlinByteArray }
+func (v BinaryType) IsBasicType() bool  { return false }
+
+// TimestampType cover the date time string value, go time.Time value,
+// proto google.protobuf.Timestamp value
+type TimestampType struct{}
+
+func (v TimestampType) Json() string       { return StrString }
+func (v TimestampType) Go() string         { return StrTime }
+func (v TimestampType) Proto() string      { return StrPbTimestamp }
+func (v TimestampType) Thrift() string     { return StrI64 }
+func (v TimestampType) TypeScript() string { return StrString }
+func (v TimestampType) JsonSchema() string { return `{"type": "string", "format": "date-time"}` }
+func (v TimestampType) Rust() string       { return StrUpperString }
+func (v TimestampType) Java() string       { return StrUpperString }
+func (v TimestampType) Kotlin() string     { return StrUpperString }
+func (v TimestampType) IsBasicType() bool  { return true }
+
+// DurationType cover the go time.Duration value, proto
+// google.protobuf.Duration value
+type DurationType struct{}
+
+func (v DurationType) Json() string       { return StrString }
+func (v DurationType) Go() string         { return StrDuration }
+func (v DurationType) Proto() string      { return StrPbDuration }
+func (v DurationType) Thrift() string     { return StrI64 }
+func (v DurationType) TypeScript() string { return StrString }
+func (v DurationType) JsonSchema() string { return `{"type": "string", "format": "duration"}` }
+func (v DurationType) Rust() string       { return StrUpperString }
+func (v DurationType) Java() string       { return StrUpperString }
+func (v DurationType) Kotlin() string     { return StrUpperString }
+func (v DurationType) IsBasicType() bool  { return true }
 
 type MapType struct {
 	Key   Type
@@ -216,56 +333,133 @@ func (v MapType) Go() string   { return fmt.Sprintf("map[%s]%s", v.Key.Go(), v.V
 func (v MapType) Proto() string {
 	return fmt.Sprintf("map<%s, %s>", v.Key.Proto(), v.Value.Proto())
 }
-func (v MapType) Thrift() string    { return fmt.Sprintf("map<%s, %s>", v.Key.Thrift(), v.Value.Thrift()) }
+func (v MapType) Thrift() string { return fmt.Sprintf("map<%s, %s>", v.Key.Thrift(), v.Value.Thrift()) }
+func (v MapType) TypeScript() string {
+	if !v.Key.IsBasicType() {
+		// Record only accepts string or number as key type
+		return fmt.Sprintf("Map<%s, %s>", v.Key.TypeScript(), v.Value.TypeScript())
+	}
+	key := v.Key.TypeScript()
+	if key != StrString && key != StrNumber {
+		// the other keys, such as boolean, are the strings in json
+		key = StrString
+	}
+	return fmt.Sprintf("Record<%s, %s>", key, v.Value.TypeScript())
+}
+func (v MapType) JsonSchema() string {
+	return fmt.Sprintf(`{"type": "object", "additionalProperties": %s}`, v.Value.JsonSchema())
+}
+func (v MapType) Rust() string {
+	return fmt.Sprintf("%s<%s, %s>", StrHashMap, v.Key.Rust(), v.Value.Rust())
+}
+func (v MapType) Java() string {
+	return fmt.Sprintf("%s<%s, %s>", StrJavaMap, v.Key.Java(), v.Value.Java())
+}
+func (v MapType) Kotlin() string {
+	return fmt.Sprintf("%s<%s, %s>", StrJavaMap, v.Key.Kotlin(), v.Value.Kotlin())
+}
 func (v MapType) IsBasicType() bool { return false }
 
 type SetType struct {
 	Key Type
 }
 
-func (v SetType) Json() string      { return "{}" }
-func (v SetType) Go() string        { return fmt.Sprintf("%s[%s]%s", StrMap, v.Key.Go(), StrBool) }
-func (v SetType) Proto() string     { return fmt.Sprintf("%s<%s, %s>", StrMap, v.Key.Proto(), StrBool) }
-func (v SetType) Thrift() string    { return fmt.Sprintf("%s<%s>", StrSet, v.Key.Thrift()) }
+func (v SetType) Json() string   { return "{}" }
+func (v SetType) Go() string     { return fmt.Sprintf("%s[%s]%s", StrMap, v.Key.Go(), StrBool) }
+func (v SetType) Proto() string  { return fmt.Sprintf("%s<%s, %s>", StrMap, v.Key.Proto(), StrBool) }
+func (v SetType) Thrift() string { return fmt.Sprintf("%s<%s>", StrSet, v.Key.Thrift()) }
+
+// TypeScript a set is serialized as an array in json payloads
+func (v SetType) TypeScript() string { return tsArray(v.Key.TypeScript()) }
+func (v SetType) JsonSchema() string {
+	return fmt.Sprintf(`{"type": "array", "items": %s, "uniqueItems": true}`, v.Key.JsonSchema())
+}
+func (v SetType) Rust() string      { return fmt.Sprintf("%s<%s>", StrHashSet, v.Key.Rust()) }
+func (v SetType) Java() string      { return fmt.Sprintf("%s<%s>", StrJavaSet, v.Key.Java()) }
+func (v SetType) Kotlin() string    { return fmt.Sprintf("%s<%s>", StrJavaSet, v.Key.Kotlin()) }
 func (v SetType) IsBasicType() bool { return false }
 
 type EnumType struct {
 	Name string
 }
 
-func (v EnumType) Json() string             { return v.Name }
-func (v EnumType) Go() string               { return v.Name }
-func (v EnumType) Proto() string            { return v.Name }
-func (v EnumType) Thrift() string           { return v.Name }
-func (v EnumType) IsBasicType() bool        { return false }
-func (v EnumType) StructName() string       { return v.Name }
-func (v EnumType) GoStructType() string     { return "enum" }
-func (v EnumType) ProtoStructType() string  { return "enum" }
-func (v EnumType) ThriftStructType() string { return "enum" }
+func (v EnumType) Json() string                 { return v.Name }
+func (v EnumType) Go() string                   { return v.Name }
+func (v EnumType) Proto() string                { return v.Name }
+func (v EnumType) Thrift() string               { return v.Name }
+func (v EnumType) TypeScript() string           { return v.Name }
+func (v EnumType) JsonSchema() string           { return jsonSchemaRef(v.Name) }
+func (v EnumType) Rust() string                 { return upperCamelName(v.Name) }
+func (v EnumType) Java() string                 { return upperCamelName(v.Name) }
+func (v EnumType) Kotlin() string               { return upperCamelName(v.Name) }
+func (v EnumType) IsBasicType() bool            { return false }
+func (v EnumType) StructName() string           { return v.Name }
+func (v EnumType) GoStructType() string         { return "enum" }
+func (v EnumType) ProtoStructType() string      { return "enum" }
+func (v EnumType) ThriftStructType() string     { return "enum" }
+func (v EnumType) TypeScriptStructType() string { return "enum" }
+func (v EnumType) JsonSchemaStructType() string { return "enum" }
 
 type StructLikeType struct {
 	Name   string
 	Source StructLikeSource
 }
 
-func (v StructLikeType) Json() string            { return v.Name }
-func (v StructLikeType) Go() string              { return "*" + goWithPackageName(v.Name) }
-func (v StructLikeType) Proto() string           { return v.Name }
-func (v StructLikeType) Thrift() string          { return v.Name }
-func (v StructLikeType) IsBasicType() bool       { return false }
-func (v StructLikeType) StructName() string      { return v.Name }
-func (v StructLikeType) GoStructType() string    { return "struct" }
-func (v StructLikeType) ProtoStructType() string { return "message" }
+func (v StructLikeType) Json() string                 { return v.Name }
+func (v StructLikeType) Go() string                   { return "*" + goWithPackageName(v.Name) }
+func (v StructLikeType) Proto() string                { return v.Name }
+func (v StructLikeType) Thrift() string               { return v.Name }
+func (v StructLikeType) TypeScript() string           { return tsWithoutPackageName(v.Name) }
+func (v StructLikeType) JsonSchema() string           { return jsonSchemaRef(v.Name) }
+func (v StructLikeType) Rust() string                 { return upperCamelName(v.Name) }
+func (v StructLikeType) Java() string                 { return upperCamelName(v.Name) }
+func (v StructLikeType) Kotlin() string               { return upperCamelName(v.Name) }
+func (v StructLikeType) IsBasicType() bool            { return false }
+func (v StructLikeType) StructName() string           { return v.Name }
+func (v StructLikeType) GoStructType() string         { return "struct" }
+func (v StructLikeType) ProtoStructType() string      { return "message" }
+func (v StructLikeType) TypeScriptStructType() string { return "interface" }
+func (v StructLikeType) JsonSchemaStructType() string { return "object" }
 func (v StructLikeType) ThriftStructType() string {
 	switch v.Source {
 	case SLSStruct:
 		return "struct"
 	case SLSUnion:
 		return "union"
+	case SLSException:
+		return "exception"
 	}
 	return "struct"
 }
 
+// IsException reports whether the struct is a thrift exception, it
+// implements the error interface in golang
+func (v StructLikeType) IsException() bool { return v.Source == SLSException }
+
+// TypedefType is a named type which is defined by a typedef, such as thrift
+// `typedef i64 UserId`. It is kept as the name in golang and thrift, and
+// resolved to the underlying type in the other languages
+type TypedefType struct {
+	Name string
+	Type Type
+}
+
+func (v TypedefType) Json() string { return v.Type.Json() }
+func (v TypedefType) Go() string {
+	if strings.HasPrefix(v.Type.Go(), "*") {
+		return "*" + v.Name
+	}
+	return v.Name
+}
+func (v TypedefType) Proto() string      { return v.Type.Proto() }
+func (v TypedefType) Thrift() string     { return v.Name }
+func (v TypedefType) TypeScript() string { return v.Type.TypeScript() }
+func (v TypedefType) JsonSchema() string { return v.Type.JsonSchema() }
+func (v TypedefType) Rust() string       { return v.Type.Rust() }
+func (v TypedefType) Java() string       { return v.Type.Java() }
+func (v TypedefType) Kotlin() string     { return v.Type.Kotlin() }
+func (v TypedefType) IsBasicType() bool  { return v.Type.IsBasicType() }
+
 func goWithPackageName(name string) string {
 	// If the name of the filed is in other package,
 	// use the last part of the package name as go's package
@@ -275,3 +469,30 @@ func goWithPackageName(name string) string {
 	}
 	return strings.Join(names, ".")
 }
+
+func tsArray(child string) string {
+	// wrap the child type which contains space, such as `Record<string, number>`,
+	// to make the `[]` suffix bind to the whole type
+	if strings.Contains(child, " ") {
+		return "Array<" + child + ">"
+	}
+	return child + "[]"
+}
+
+func tsWithoutPackageName(name string) string {
+	// typescript has no package, use the last part of the name as the type name
+	names := strings.Split(name, ".")
+	return names[len(names)-1]
+}
+
+// upperCamelName get the type name of rust, java and kotlin, the package is
+// dropped and the last part of the name is converted to the upper camel case
+func upperCamelName(name string) string {
+	return strcase.ToCamel(snake(normalizeToken(tsWithoutPackageName(name), "_")))
+}
+
+const jsonSchemaInteger = `{"type": "integer"}`
+
+func jsonSchemaRef(name string) string {
+	return fmt.Sprintf(`{"$ref": "#/$defs/%s"}`, name)
+}
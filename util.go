@@ -1,7 +1,11 @@
 package st2
 
 import (
+	"encoding/base64"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/iancoleman/strcase"
 )
@@ -126,6 +130,168 @@ var acronyms = map[string]bool{
 	"XSS":   true,
 }
 
+// rustKeywords is the strict and reserved keywords of rust, they are
+// escaped as the raw identifiers
+var rustKeywords = map[string]bool{
+	"abstract": true,
+	"as":       true,
+	"async":    true,
+	"await":    true,
+	"become":   true,
+	"box":      true,
+	"break":    true,
+	"const":    true,
+	"continue": true,
+	"do":       true,
+	"dyn":      true,
+	"else":     true,
+	"enum":     true,
+	"extern":   true,
+	"false":    true,
+	"final":    true,
+	"fn":       true,
+	"for":      true,
+	"gen":      true,
+	"if":       true,
+	"impl":     true,
+	"in":       true,
+	"let":      true,
+	"loop":     true,
+	"macro":    true,
+	"match":    true,
+	"mod":      true,
+	"move":     true,
+	"mut":      true,
+	"override": true,
+	"priv":     true,
+	"pub":      true,
+	"ref":      true,
+	"return":   true,
+	"static":   true,
+	"struct":   true,
+	"trait":    true,
+	"true":     true,
+	"try":      true,
+	"type":     true,
+	"typeof":   true,
+	"unsafe":   true,
+	"unsized":  true,
+	"use":      true,
+	"virtual":  true,
+	"where":    true,
+	"while":    true,
+	"yield":    true,
+}
+
+// rustReservedKeywords is the keywords of rust which can not be raw
+// identifiers
+var rustReservedKeywords = map[string]bool{
+	"crate": true,
+	"self":  true,
+	"super": true,
+}
+
+// javaKeywords is the reserved keywords and literals of java
+var javaKeywords = map[string]bool{
+	"abstract":     true,
+	"assert":       true,
+	"boolean":      true,
+	"break":        true,
+	"byte":         true,
+	"case":         true,
+	"catch":        true,
+	"char":         true,
+	"class":        true,
+	"const":        true,
+	"continue":     true,
+	"default":      true,
+	"do":           true,
+	"double":       true,
+	"else":         true,
+	"enum":         true,
+	"extends":      true,
+	"false":        true,
+	"final":        true,
+	"finally":      true,
+	"float":        true,
+	"for":          true,
+	"goto":         true,
+	"if":           true,
+	"implements":   true,
+	"import":       true,
+	"instanceof":   true,
+	"int":          true,
+	"interface":    true,
+	"long":         true,
+	"native":       true,
+	"new":          true,
+	"null":         true,
+	"package":      true,
+	"private":      true,
+	"protected":    true,
+	"public":       true,
+	"return":       true,
+	"short":        true,
+	"static":       true,
+	"strictfp":     true,
+	"super":        true,
+	"switch":       true,
+	"synchronized": true,
+	"this":         true,
+	"throw":        true,
+	"throws":       true,
+	"transient":    true,
+	"true":         true,
+	"try":          true,
+	"void":         true,
+	"volatile":     true,
+	"while":        true,
+}
+
+// javaPrimitives map the boxed types of java to the primitive types
+var javaPrimitives = map[string]string{
+	StrJavaBoolean: "boolean",
+	StrJavaByte:    "byte",
+	StrJavaShort:   "short",
+	StrJavaInteger: "int",
+	StrJavaLong:    "long",
+	StrJavaFloat:   "float",
+	StrJavaDouble:  "double",
+}
+
+// kotlinKeywords is the hard keywords of kotlin, they are escaped by
+// backticks
+var kotlinKeywords = map[string]bool{
+	"as":        true,
+	"break":     true,
+	"class":     true,
+	"continue":  true,
+	"do":        true,
+	"else":      true,
+	"false":     true,
+	"for":       true,
+	"fun":       true,
+	"if":        true,
+	"in":        true,
+	"interface": true,
+	"is":        true,
+	"null":      true,
+	"object":    true,
+	"package":   true,
+	"return":    true,
+	"super":     true,
+	"this":      true,
+	"throw":     true,
+	"true":      true,
+	"try":       true,
+	"typealias": true,
+	"typeof":    true,
+	"val":       true,
+	"var":       true,
+	"when":      true,
+	"while":     true,
+}
+
 func camel(s string) string {
 	items := strings.Split(s, "_")
 	for i, item := range items {
@@ -170,3 +336,41 @@ func normalizeToken(token string, emptyDefault string) string {
 
 	return token
 }
+
+var (
+	uuidRegexp   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	base64Regexp = regexp.MustCompile(`^[A-Za-z0-9+/]+={0,2}$`)
+	letterRegexp = regexp.MustCompile(`^[A-Za-z]+$`)
+	upperRegexp  = regexp.MustCompile(`[A-Z]`)
+	lowerRegexp  = regexp.MustCompile(`[a-z]`)
+)
+
+// detectFormat detect the well-known format of the string value,
+// it returns empty string if no format is detected
+func detectFormat(str string) string {
+	if _, err := time.Parse(time.RFC3339Nano, str); err == nil {
+		return FormatDateTime
+	}
+
+	if uuidRegexp.MatchString(str) {
+		return FormatUUID
+	}
+
+	if u, err := url.Parse(str); err == nil && u.Scheme != "" && u.Host != "" {
+		return FormatURL
+	}
+
+	// a short string or a word is likely to be a plain text, and a number
+	// or a hex string needs the base64 specific characters or mixed case
+	if len(str) >= 16 && len(str)%4 == 0 &&
+		base64Regexp.MatchString(str) &&
+		!letterRegexp.MatchString(strings.TrimRight(str, "=")) &&
+		(strings.ContainsAny(str, "+/=") ||
+			upperRegexp.MatchString(str) && lowerRegexp.MatchString(str)) {
+		if _, err := base64.StdEncoding.DecodeString(str); err == nil {
+			return FormatBase64
+		}
+	}
+
+	return ""
+}
@@ -207,3 +207,154 @@ func TestSnake(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectFormat(t *testing.T) {
+	type args struct {
+		str string
+	}
+	tests := []struct {
+		name string
+		args func(t *testing.T) args
+
+		want1 string
+	}{
+		{
+			name: "empty",
+			args: func(t *testing.T) args {
+				return args{
+					str: "",
+				}
+			},
+			want1: "",
+		},
+		{
+			name: "plain text",
+			args: func(t *testing.T) args {
+				return args{
+					str: "hello world",
+				}
+			},
+			want1: "",
+		},
+		{
+			name: "date time",
+			args: func(t *testing.T) args {
+				return args{
+					str: "2024-01-02T15:04:05Z",
+				}
+			},
+			want1: FormatDateTime,
+		},
+		{
+			name: "date time with nano",
+			args: func(t *testing.T) args {
+				return args{
+					str: "2024-01-02T15:04:05.123+08:00",
+				}
+			},
+			want1: FormatDateTime,
+		},
+		{
+			name: "uuid",
+			args: func(t *testing.T) args {
+				return args{
+					str: "123e4567-e89b-12d3-a456-426614174000",
+				}
+			},
+			want1: FormatUUID,
+		},
+		{
+			name: "url",
+			args: func(t *testing.T) args {
+				return args{
+					str: "https://github.com/tenfyzhong/st2",
+				}
+			},
+			want1: FormatURL,
+		},
+		{
+			name: "url without host",
+			args: func(t *testing.T) args {
+				return args{
+					str: "mailto:a@b.c",
+				}
+			},
+			want1: "",
+		},
+		{
+			name: "base64",
+			args: func(t *testing.T) args {
+				return args{
+					str: "aGVsbG8gd29ybGQgaGVsbG8=",
+				}
+			},
+			want1: FormatBase64,
+		},
+		{
+			name: "short base64",
+			args: func(t *testing.T) args {
+				return args{
+					str: "aGVsbG8=",
+				}
+			},
+			want1: "",
+		},
+		{
+			name: "long word",
+			args: func(t *testing.T) args {
+				return args{
+					str: "abcdefghijklmnop",
+				}
+			},
+			want1: "",
+		},
+		{
+			name: "base64 without padding",
+			args: func(t *testing.T) args {
+				return args{
+					str: "aGVsbG8gV29ybGQh",
+				}
+			},
+			want1: FormatBase64,
+		},
+		{
+			name: "digits",
+			args: func(t *testing.T) args {
+				return args{
+					str: "2024010112345678",
+				}
+			},
+			want1: "",
+		},
+		{
+			name: "hex",
+			args: func(t *testing.T) args {
+				return args{
+					str: "0123456789abcdef",
+				}
+			},
+			want1: "",
+		},
+		{
+			name: "upper hex",
+			args: func(t *testing.T) args {
+				return args{
+					str: "0123456789ABCDEF",
+				}
+			},
+			want1: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tArgs := tt.args(t)
+
+			got1 := detectFormat(tArgs.str)
+
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("detectFormat got1 = %v, want1: %v", got1, tt.want1)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package st2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValueKind is the kind of [Value]
+type ValueKind int
+
+const (
+	ValueInt ValueKind = iota
+	ValueDouble
+	ValueString
+	// ValueIdentifier is a reference to a constant or an enum value, such
+	// as `MAX` or `Status.OK`, `true` and `false` are also identifiers
+	ValueIdentifier
+	ValueList
+	ValueMap
+)
+
+// Value is a constant value or a default value of field
+type Value struct {
+	Kind ValueKind
+	// Text is the literal of int, double and identifier, or the unquoted
+	// string
+	Text string
+	List []*Value
+	Map  []*MapValue
+}
+
+// MapValue is a key value pair of a map [Value]
+type MapValue struct {
+	Key   *Value
+	Value *Value
+}
+
+// Go get the golang literal of the value with the type t
+func (v *Value) Go(t Type) string {
+	switch v.Kind {
+	case ValueString:
+		return strconv.Quote(v.Text)
+	case ValueIdentifier:
+		if e, ok := underlyingType(t).(*EnumType); ok {
+			// the enum value of the included file is the constant of the
+			// included package, such as `shared.Color.RED` is
+			// `shared.ColorRED`
+			pkg, name, included := strings.Cut(e.Name, ".")
+			if value, found := strings.CutPrefix(v.Text, e.Name+"."); included && found {
+				return pkg + "." + name + camel(value)
+			}
+		}
+		index := strings.LastIndex(v.Text, ".")
+		if index < 0 {
+			return v.Text
+		}
+		// the enum value is declared as a golang constant
+		return camel(v.Text[index+1:])
+	case ValueList:
+		items := make([]string, 0, len(v.List))
+		switch t := underlyingType(t).(type) {
+		case *ArrayType:
+			for _, item := range v.List {
+				items = append(items, item.Go(t.ChildType))
+			}
+		case *SetType:
+			for _, item := range v.List {
+				items = append(items, item.Go(t.Key)+": true")
+			}
+		default:
+			return ""
+		}
+		return fmt.Sprintf("%s{%s}", goCompositeType(t), strings.Join(items, ", "))
+	case ValueMap:
+		m, ok := underlyingType(t).(*MapType)
+		if !ok {
+			return ""
+		}
+		items := make([]string, 0, len(v.Map))
+		for _, item := range v.Map {
+			items = append(items, item.Key.Go(m.Key)+": "+item.Value.Go(m.Value))
+		}
+		return fmt.Sprintf("%s{%s}", goCompositeType(t), strings.Join(items, ", "))
+	}
+	return v.Text
+}
+
+// Thrift get the thrift literal of the value
+func (v *Value) Thrift() string {
+	switch v.Kind {
+	case ValueString:
+		return strconv.Quote(v.Text)
+	case ValueList:
+		items := make([]string, 0, len(v.List))
+		for _, item := range v.List {
+			items = append(items, item.Thrift())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case ValueMap:
+		items := make([]string, 0, len(v.Map))
+		for _, item := range v.Map {
+			items = append(items, item.Key.Thrift()+": "+item.Value.Thrift())
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	}
+	return v.Text
+}
+
+// Constant is a constant declaration, such as thrift `const i32 MAX = 10`
+type Constant struct {
+	Name    string
+	Type    Type
+	Value   *Value
+	Comment Comment
+}
+
+// GoValue get the golang literal of the constant value
+func (c Constant) GoValue() string {
+	return c.Value.Go(c.Type)
+}
+
+// IsGoConst reports whether the constant can be declared as a golang const,
+// the other constants are declared as variables
+func (c Constant) IsGoConst() bool {
+	switch t := underlyingType(c.Type).(type) {
+	case *EnumType:
+		return true
+	case *BinaryType, *TimestampType:
+		return false
+	default:
+		return t.IsBasicType()
+	}
+}
+
+// underlyingType get the type defined by the typedef
+func underlyingType(t Type) Type {
+	for {
+		typedef, ok := t.(*TypedefType)
+		if !ok {
+			return t
+		}
+		t = typedef.Type
+	}
+}
+
+// goCompositeType get the type of golang composite literal, the typedef
+// name is kept
+func goCompositeType(t Type) string {
+	return strings.TrimPrefix(t.Go(), "*")
+}
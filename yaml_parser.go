@@ -1,6 +1,9 @@
 package st2
 
 import (
+	"bytes"
+	"io"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -10,6 +13,24 @@ func (j YamlUnmarshalTagFormat) Unmarshal(data []byte, v any) error {
 	return yaml.Unmarshal(data, v)
 }
 
+// UnmarshalSamples unmarshal a multi-document yaml
+func (j YamlUnmarshalTagFormat) UnmarshalSamples(data []byte) ([]any, error) {
+	samples := make([]any, 0)
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var v any
+		err := decoder.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, v)
+	}
+	return samples, nil
+}
+
 func (j YamlUnmarshalTagFormat) TagFormat() string {
 	return `yaml:"%s"`
 }